@@ -4,16 +4,32 @@ import (
 	gg "github.com/vcharco/gocli/internal/core"
 	gt "github.com/vcharco/gocli/internal/types"
 	gu "github.com/vcharco/gocli/internal/utils"
+	gv "github.com/vcharco/gocli/internal/validation"
 )
 
 type Terminal = gg.Terminal
 type TerminalResponse = gg.TerminalResponse
 type TerminalStyles = gg.TerminalStyles
 type TerminalResponseType = gg.TerminalResponseType
+type Highlighter = gg.Highlighter
+type HistoryOptions = gg.HistoryOptions
+type HistoryDedupe = gg.HistoryDedupe
+type HistoryEntry = gg.HistoryEntry
+type HistoryStore = gg.HistoryStore
+type HistoryQuery = gg.HistoryQuery
+type MemoryHistoryStore = gg.MemoryHistoryStore
+type FileHistoryStore = gg.FileHistoryStore
+type HighlighterFunc = gg.HighlighterFunc
 type Command = gt.Command
 type Param = gt.Param
 type ParamModifier = gt.ParamModifier
 type ParamType = gt.ParamType
+type TypeDefinition = gv.TypeDefinition
+type ParamGroup = gt.ParamGroup
+type ParamGroupType = gt.ParamGroupType
+type ParamSource = gt.ParamSource
+type ValidationError = gv.ValidationError
+type ErrorCode = gv.ErrorCode
 
 const (
 	Date        = gt.Date
@@ -28,6 +44,17 @@ const (
 	Time        = gt.Time
 	Url         = gt.Url
 	UUID        = gt.UUID
+	Choice      = gt.Choice
+	Duration    = gt.Duration
+	Bool        = gt.Bool
+	Path        = gt.Path
+	CIDR        = gt.CIDR
+	Port        = gt.Port
+	Hex         = gt.Hex
+	Size        = gt.Size
+	DateTime    = gt.DateTime
+	Regex       = gt.Regex
+	JSON        = gt.JSON
 )
 
 const (
@@ -101,8 +128,46 @@ const (
 )
 
 const (
-	DEFAULT  = gt.DEFAULT
-	REQUIRED = gt.REQUIRED
+	DEFAULT    = gt.DEFAULT
+	REQUIRED   = gt.REQUIRED
+	VARIADIC   = gt.VARIADIC
+	REPEATABLE = gt.REPEATABLE
+	LIST       = gt.LIST
+)
+
+const (
+	FromInput   = gt.FromInput
+	FromEnv     = gt.FromEnv
+	FromDefault = gt.FromDefault
+)
+
+const (
+	SyntaxError         = gv.SyntaxError
+	EmptyCommand        = gv.EmptyCommand
+	UnknownCommand      = gv.UnknownCommand
+	ParamsNotSupported  = gv.ParamsNotSupported
+	UnknownParam        = gv.UnknownParam
+	MissingValue        = gv.MissingValue
+	UnexpectedValue     = gv.UnexpectedValue
+	InvalidValue        = gv.InvalidValue
+	ConstraintViolation = gv.ConstraintViolation
+	MissingParam        = gv.MissingParam
+	InvalidCount        = gv.InvalidCount
+	GroupViolation      = gv.GroupViolation
+	InvalidEnvValue     = gv.InvalidEnvValue
+	InvalidDefinition   = gv.InvalidDefinition
+)
+
+const (
+	KeepDuplicates   = gg.KeepDuplicates
+	IgnoreDuplicates = gg.IgnoreDuplicates
+	EraseDuplicates  = gg.EraseDuplicates
+)
+
+const (
+	MutuallyExclusive = gt.MutuallyExclusive
+	AtLeastOne        = gt.AtLeastOne
+	Requires          = gt.Requires
 )
 
 const (
@@ -134,3 +199,46 @@ const (
 	Ctrl_Z = gt.Ctrl_Z
 	Escape = gt.Escape
 )
+
+// RegisterType adds a custom param type. Use the returned value as the Type of the params.
+func RegisterType(definition TypeDefinition) ParamType {
+	return gv.RegisterType(definition)
+}
+
+// Bound returns a pointer to the value, to be used in the Min and Max fields of a Param
+func Bound(value float64) *float64 {
+	return gt.Bound(value)
+}
+
+// ParamAs returns the value of the param when it was given and its type is T
+func ParamAs[T any](tr *TerminalResponse, name string) (T, bool) {
+	return gg.ParamAs[T](tr, name)
+}
+
+// ParamsFromStruct builds a list of params from the fields of a struct tagged with
+// gocli:"name,options". Use TerminalResponse.Bind to fill the struct with the values.
+func ParamsFromStruct(v interface{}) ([]Param, error) {
+	return gv.ParamsFromStruct(v)
+}
+
+// MustParamsFromStruct is like ParamsFromStruct but panics on error, so it can be
+// used when declaring the commands
+func MustParamsFromStruct(v interface{}) []Param {
+	params, err := gv.ParamsFromStruct(v)
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// NewMemoryHistoryStore keeps the history in memory, applying the MaxEntries and
+// Dedupe options
+func NewMemoryHistoryStore(options HistoryOptions) *MemoryHistoryStore {
+	return gg.NewMemoryHistoryStore(options)
+}
+
+// NewFileHistoryStore keeps the history in the File of the options, applying the
+// MaxEntries and Dedupe options
+func NewFileHistoryStore(options HistoryOptions) *FileHistoryStore {
+	return gg.NewFileHistoryStore(options)
+}
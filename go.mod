@@ -2,6 +2,7 @@ module github.com/vcharco/gocli
 
 go 1.23.1
 
-require golang.org/x/term v0.27.0
-
-require golang.org/x/sys v0.28.0 // indirect
+require (
+	golang.org/x/sys v0.28.0
+	golang.org/x/term v0.27.0
+)
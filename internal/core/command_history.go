@@ -3,87 +3,175 @@ package gocli
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
+// HistoryEntry is a command of the history along with the context it was typed in
+type HistoryEntry struct {
+	Command string `json:"command"`
+	// When the command was submitted
+	Time time.Time `json:"time"`
+	// Time since the prompt was displayed until the command was submitted
+	TypingTime   time.Duration        `json:"typing_time"`
+	ResponseType TerminalResponseType `json:"type"`
+	// Validation error, if any
+	Error      string            `json:"error,omitempty"`
+	WorkingDir string            `json:"dir,omitempty"`
+	Meta       map[string]string `json:"meta,omitempty"`
+	// How long the command ran and the error it failed with, as reported by the
+	// program with CompleteHistory
+	Duration  time.Duration `json:"duration,omitempty"`
+	ExitError string        `json:"exit_error,omitempty"`
+	Completed bool          `json:"completed,omitempty"`
+}
+
 type commandHistory struct {
-	Commands      []string
+	Entries       []HistoryEntry
 	CurrentIndex  int
 	Cache         string
 	IsCacheActive bool
+	Prefix        string
 }
 
-func (c *commandHistory) append(command string) {
-	c.Commands = append(c.Commands, command)
+func (c *commandHistory) clear() {
+	c.Entries = []HistoryEntry{}
 	c.resetIndex()
 }
 
-func (c *commandHistory) clear() {
-	c.Commands = []string{}
-	c.resetIndex()
+func (c *commandHistory) getCommands() []string {
+	commands := make([]string, len(c.Entries))
+	for i, entry := range c.Entries {
+		commands[i] = entry.Command
+	}
+	return commands
 }
 
-func (c *commandHistory) getPrev(currentCommand string) (string, error) {
-	if c.CurrentIndex <= 0 {
+// Only the commands starting with the prefix are visited. The prefix is taken
+// when the navigation starts, and an empty one visits all the commands.
+func (c *commandHistory) getPrev(currentCommand string, prefix string) (string, error) {
+	if c.CurrentIndex == len(c.Entries) {
+		c.Prefix = prefix
+	}
+
+	index := c.CurrentIndex - 1
+	for index >= 0 && !strings.HasPrefix(c.Entries[index].Command, c.Prefix) {
+		index--
+	}
+	if index < 0 {
 		return "", fmt.Errorf("no previous commands")
 	}
 
-	if c.CurrentIndex == len(c.Commands) {
+	if c.CurrentIndex == len(c.Entries) {
 		c.Cache = currentCommand
 		c.IsCacheActive = true
 	}
 
-	c.CurrentIndex -= 1
-	return c.Commands[c.CurrentIndex], nil
+	c.CurrentIndex = index
+	return c.Entries[c.CurrentIndex].Command, nil
 }
 
 func (c *commandHistory) getNext() (string, error) {
-	if c.CurrentIndex >= len(c.Commands)-1 {
+	index := c.CurrentIndex + 1
+	for index < len(c.Entries) && !strings.HasPrefix(c.Entries[index].Command, c.Prefix) {
+		index++
+	}
+
+	if index >= len(c.Entries) {
 		if c.IsCacheActive {
 			c.IsCacheActive = false
-			c.CurrentIndex += 1
+			c.CurrentIndex = len(c.Entries)
 			return c.Cache, nil
 		}
 		return "", fmt.Errorf("no more commands")
 	}
 
-	c.CurrentIndex += 1
-	if c.CurrentIndex < len(c.Commands) {
-		return c.Commands[c.CurrentIndex], nil
-	}
-
-	return "", fmt.Errorf("already at the most recent command")
+	c.CurrentIndex = index
+	return c.Entries[c.CurrentIndex].Command, nil
 }
 
 func (c *commandHistory) resetIndex() {
-	c.CurrentIndex = len(c.Commands)
+	c.CurrentIndex = len(c.Entries)
 }
 
+// PrintHistory prints the last commands. When the TimeFormat of the history is
+// set, the time and the duration of each one are printed too, the duration
+// being empty until the command is completed.
 func (t *Terminal) PrintHistory(limit int) {
-	if limit == 0 || limit > len(t.commandHistory.Commands) {
-		limit = len(t.commandHistory.Commands)
+	entries := t.commandHistory.Entries
+	if limit == 0 || limit > len(entries) {
+		limit = len(entries)
 	}
 
-	start := len(t.commandHistory.Commands) - limit
-	for i := start; i < len(t.commandHistory.Commands); i++ {
-		fmt.Println(t.commandHistory.Commands[i])
+	start := len(entries) - limit
+	for i := start; i < len(entries); i++ {
+		if len(t.History.TimeFormat) == 0 {
+			fmt.Println(entries[i].Command)
+			continue
+		}
+		duration := ""
+		if entries[i].Completed {
+			duration = entries[i].Duration.Round(time.Millisecond).String()
+		}
+		fmt.Printf("%v  %8v  %v\n", entries[i].Time.Format(t.History.TimeFormat), duration, entries[i].Command)
 	}
 }
 
-func (t *Terminal) ClearHistory() {
+// ClearHistory also clears the store of the history, like the history file
+func (t *Terminal) ClearHistory() error {
 	t.commandHistory.clear()
+	return t.historyStore.Clear()
+}
+
+// CompleteHistory records how long the last command ran and the error it failed
+// with, if any. It does nothing when the last command was not recorded, like a
+// line ignored by the history policies.
+func (t *Terminal) CompleteHistory(duration time.Duration, err error) error {
+	if t.lastHistoryEntry == nil {
+		return nil
+	}
+
+	entry := *t.lastHistoryEntry
+	t.lastHistoryEntry = nil
+	entry.Duration = duration
+	entry.Completed = true
+	if err != nil {
+		entry.ExitError = err.Error()
+	}
+
+	entries, storeErr := t.historyStore.Update(entry)
+	if storeErr != nil {
+		replaceHistoryEntry(t.commandHistory.Entries, entry)
+		return storeErr
+	}
+	t.commandHistory.Entries = entries
+	t.commandHistory.resetIndex()
+	return nil
 }
 
 func (t *Terminal) CountHistory() int {
-	return len(t.commandHistory.Commands)
+	return len(t.commandHistory.Entries)
 }
 
 func (t *Terminal) GetHistoryAt(index int) (string, error) {
-	if index < 0 || index >= len(t.commandHistory.Commands) {
+	if index < 0 || index >= len(t.commandHistory.Entries) {
 		return "", errors.New("index out of range")
 	}
-	return t.commandHistory.Commands[index], nil
+	return t.commandHistory.Entries[index].Command, nil
+}
+
+func (t *Terminal) GetHistory() []HistoryEntry {
+	return t.commandHistory.Entries
+}
+
+// GetHistoryBetween returns the entries submitted in the time range. A zero
+// from or to leaves that side of the range open.
+func (t *Terminal) GetHistoryBetween(from time.Time, to time.Time) []HistoryEntry {
+	return HistoryQuery{From: from, To: to}.Filter(t.commandHistory.Entries)
 }
 
-func (t *Terminal) GetHistory(index int) []string {
-	return t.commandHistory.Commands
+// SearchHistory runs the query against the store, so it may find the entries
+// recorded by other sessions
+func (t *Terminal) SearchHistory(query HistoryQuery) ([]HistoryEntry, error) {
+	return t.historyStore.Search(query)
 }
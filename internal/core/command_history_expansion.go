@@ -0,0 +1,123 @@
+package gocli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+// Expands the references to the history the way bash does: !! is the last
+// command, !n the command at index n, !prefix the last command starting with the
+// prefix, !$ the last argument of the last command and ^old^new the last command
+// with the first old replaced by new. It returns whether the line changed.
+func expandHistory(line string, commands []string) (string, bool, error) {
+	if strings.HasPrefix(line, "^") {
+		return substituteLastCommand(line, commands)
+	}
+
+	var expanded strings.Builder
+	changed := false
+	// As in bash, references are expanded inside double quotes but not inside
+	// single quotes, and an apostrophe inside double quotes is not a quote
+	inQuotes := false
+	inDoubleQuotes := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case c == '\'' && !inDoubleQuotes:
+			inQuotes = !inQuotes
+		case c == '"' && !inQuotes:
+			inDoubleQuotes = !inDoubleQuotes
+		case c == '\\' && i+1 < len(line) && !inQuotes:
+			expanded.WriteByte(c)
+			i++
+			c = line[i]
+		case c == '!' && i+1 < len(line) && !inQuotes:
+			event, length := getHistoryEvent(line[i+1:])
+			if length == 0 {
+				break
+			}
+			value, err := resolveHistoryEvent(event, commands)
+			if err != nil {
+				return line, false, err
+			}
+			expanded.WriteString(value)
+			changed = true
+			i += length
+			continue
+		}
+
+		expanded.WriteByte(c)
+	}
+
+	return expanded.String(), changed, nil
+}
+
+// Returns the event after a ! and its length, which is 0 when it isn't an event
+func getHistoryEvent(text string) (string, int) {
+	switch text[0] {
+	case '!', '$':
+		return text[:1], 1
+	}
+
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("!'\"=()", r)
+	})
+	if end == -1 {
+		end = len(text)
+	}
+	return text[:end], end
+}
+
+func resolveHistoryEvent(event string, commands []string) (string, error) {
+	notFound := fmt.Errorf("!%v: event not found", event)
+	if len(commands) == 0 {
+		return "", notFound
+	}
+	last := commands[len(commands)-1]
+
+	switch event {
+	case "!":
+		return last, nil
+	case "$":
+		tokens, _ := gu.Tokenize(last)
+		if len(tokens) == 0 {
+			return "", notFound
+		}
+		return last[tokens[len(tokens)-1].Start:tokens[len(tokens)-1].End], nil
+	}
+
+	if index, err := strconv.Atoi(event); err == nil {
+		if index < 0 || index >= len(commands) {
+			return "", notFound
+		}
+		return commands[index], nil
+	}
+
+	for i := len(commands) - 1; i >= 0; i-- {
+		if strings.HasPrefix(commands[i], event) {
+			return commands[i], nil
+		}
+	}
+	return "", notFound
+}
+
+// Parses ^old^new (optionally ending with ^) and replaces the first old of the
+// last command
+func substituteLastCommand(line string, commands []string) (string, bool, error) {
+	old, replacement, found := strings.Cut(line[1:], "^")
+	if !found || len(old) == 0 {
+		return line, false, nil
+	}
+	replacement = strings.TrimSuffix(replacement, "^")
+
+	if len(commands) == 0 || !strings.Contains(commands[len(commands)-1], old) {
+		return line, false, fmt.Errorf("^%v^%v: substitution failed", old, replacement)
+	}
+	return strings.Replace(commands[len(commands)-1], old, replacement, 1), true, nil
+}
@@ -0,0 +1,67 @@
+package gocli
+
+import "testing"
+
+func TestExpandHistory(t *testing.T) {
+	commands := []string{"copy a.txt b.txt", "move 'x y' z", "print-history 20"}
+
+	tests := []struct {
+		line     string
+		expanded string
+		changed  bool
+	}{
+		{"foo", "foo", false},
+		{"!!", "print-history 20", true},
+		{"sudo !!", "sudo print-history 20", true},
+		{"!0", "copy a.txt b.txt", true},
+		{"!1 -f", "move 'x y' z -f", true},
+		{"!cop", "copy a.txt b.txt", true},
+		{"!mo", "move 'x y' z", true},
+		{"echo !$", "echo 20", true},
+		{"echo '!!'", "echo '!!'", false},
+		{`echo "it's !!"`, `echo "it's print-history 20"`, true},
+		{`echo "!!"`, `echo "print-history 20"`, true},
+		{`echo 'a "!!" b'`, `echo 'a "!!" b'`, false},
+		{`echo \!!`, `echo \!!`, false},
+		{"echo !", "echo !", false},
+		{"echo ! x", "echo ! x", false},
+		{"^20^30", "print-history 30", true},
+		{"^20^30^", "print-history 30", true},
+		{"^history^^", "print- 20", true},
+	}
+
+	for _, test := range tests {
+		expanded, changed, err := expandHistory(test.line, commands)
+		if err != nil {
+			t.Errorf("expandHistory(%q) returned error %v", test.line, err)
+			continue
+		}
+		if expanded != test.expanded || changed != test.changed {
+			t.Errorf("expandHistory(%q) = %q, %v, want %q, %v", test.line, expanded, changed, test.expanded, test.changed)
+		}
+	}
+}
+
+func TestExpandHistoryErrors(t *testing.T) {
+	commands := []string{"copy a b"}
+
+	tests := []struct {
+		line     string
+		commands []string
+		err      string
+	}{
+		{"!!", nil, "!!: event not found"},
+		{"!5", commands, "!5: event not found"},
+		{"!-1", commands, "!-1: event not found"},
+		{"!zz", commands, "!zz: event not found"},
+		{"^x^y", commands, "^x^y: substitution failed"},
+		{"^x^y", nil, "^x^y: substitution failed"},
+	}
+
+	for _, test := range tests {
+		_, _, err := expandHistory(test.line, test.commands)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("expandHistory(%q) returned error %v, want %v", test.line, err, test.err)
+		}
+	}
+}
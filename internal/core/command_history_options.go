@@ -0,0 +1,102 @@
+package gocli
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type HistoryDedupe int
+
+const (
+	// Every command is recorded
+	KeepDuplicates HistoryDedupe = iota
+	// A command is not recorded when it is the same as the previous one
+	IgnoreDuplicates
+	// The older copies of a command are removed when it is recorded
+	EraseDuplicates
+)
+
+type HistoryOptions struct {
+	// File where the commands are kept between sessions, one JSON entry per line.
+	// Several sessions may share it, as it is locked while written.
+	File string
+	// Maximum number of commands kept, 0 means no limit
+	MaxEntries int
+	// When the line is not empty, UP and DOWN only visit the commands starting
+	// with the text before the cursor
+	PrefixSearch bool
+	Dedupe       HistoryDedupe
+	// Lines starting with a space are not recorded
+	IgnoreSpace bool
+	// Lines matching any of the patterns are not recorded, like the ones with secrets
+	IgnorePatterns []*regexp.Regexp
+	// Lines that fail the validation are not recorded
+	SkipErrors bool
+	// Expands !!, !n, !prefix, !$ and ^old^new before running the command
+	Expansion bool
+	// Layout of the time column of PrintHistory, which is not printed when empty
+	TimeFormat string
+	// Returns the metadata recorded with each command, like the current user
+	Meta func() map[string]string
+	// Where the entries are kept. When nil, they are kept in the File or, without
+	// it, in memory.
+	Store HistoryStore
+}
+
+// Returns the store of the options or the default one
+func getHistoryStore(options HistoryOptions) HistoryStore {
+	if options.Store != nil {
+		return options.Store
+	}
+	if len(options.File) > 0 {
+		return NewFileHistoryStore(options)
+	}
+	return NewMemoryHistoryStore(options)
+}
+
+// Loads the entries of the store. Errors are ignored, so the terminal works with
+// an empty history when the store cannot be read.
+func (t *Terminal) loadHistory() {
+	entries, err := t.historyStore.Load()
+	if err != nil {
+		return
+	}
+
+	t.commandHistory.Entries = entries
+	t.commandHistory.resetIndex()
+}
+
+// Adds the command to the history. The entries returned by the store replace
+// the ones in memory, so the commands of other sessions are merged too.
+func (t *Terminal) appendHistory(command string, responseType TerminalResponseType, err error) {
+	defer t.commandHistory.resetIndex()
+	t.lastHistoryEntry = nil
+
+	command = strings.ReplaceAll(command, "\n", " ")
+	for _, pattern := range t.History.IgnorePatterns {
+		if pattern.MatchString(command) {
+			return
+		}
+	}
+
+	entry := HistoryEntry{Command: command, Time: time.Now(), ResponseType: responseType}
+	entry.TypingTime = entry.Time.Sub(t.promptTime)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	entry.WorkingDir, _ = os.Getwd()
+	if t.History.Meta != nil {
+		entry.Meta = t.History.Meta()
+	}
+
+	t.lastHistoryEntry = &entry
+
+	if entries, err := t.historyStore.Append(entry); err == nil {
+		t.commandHistory.Entries = entries
+		return
+	}
+
+	t.commandHistory.Entries, _, _ = addHistoryEntry(t.commandHistory.Entries, entry, t.History)
+}
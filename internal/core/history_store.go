@@ -0,0 +1,146 @@
+package gocli
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HistoryStore keeps the entries of the command history. The terminal loads
+// them when it starts and appends each command as it is submitted.
+type HistoryStore interface {
+	// Append records the entry and returns the whole history, which may include
+	// the entries recorded by other sessions
+	Append(entry HistoryEntry) ([]HistoryEntry, error)
+	// Update replaces the entry with the same Time and Command, if any, and
+	// returns the whole history
+	Update(entry HistoryEntry) ([]HistoryEntry, error)
+	Load() ([]HistoryEntry, error)
+	Search(query HistoryQuery) ([]HistoryEntry, error)
+	Clear() error
+}
+
+// HistoryQuery filters the entries of the history. Empty fields don't filter.
+type HistoryQuery struct {
+	// Text contained in the command
+	Text string
+	// Text the command starts with
+	Prefix string
+	From   time.Time
+	To     time.Time
+	// Maximum number of entries, the most recent ones
+	Limit int
+}
+
+func (q HistoryQuery) Match(entry HistoryEntry) bool {
+	return strings.Contains(entry.Command, q.Text) &&
+		strings.HasPrefix(entry.Command, q.Prefix) &&
+		(q.From.IsZero() || !entry.Time.Before(q.From)) &&
+		(q.To.IsZero() || !entry.Time.After(q.To))
+}
+
+// Filter returns the entries matching the query, keeping their order
+func (q HistoryQuery) Filter(entries []HistoryEntry) []HistoryEntry {
+	var result []HistoryEntry
+	for _, entry := range entries {
+		if q.Match(entry) {
+			result = append(result, entry)
+		}
+	}
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[len(result)-q.Limit:]
+	}
+	return result
+}
+
+// MemoryHistoryStore keeps the history while the program runs
+type MemoryHistoryStore struct {
+	options HistoryOptions
+	entries []HistoryEntry
+	mutex   sync.Mutex
+}
+
+// NewMemoryHistoryStore creates a store applying the MaxEntries and Dedupe options
+func NewMemoryHistoryStore(options HistoryOptions) *MemoryHistoryStore {
+	return &MemoryHistoryStore{options: options}
+}
+
+func (s *MemoryHistoryStore) Append(entry HistoryEntry) ([]HistoryEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries, _, _ = addHistoryEntry(s.entries, entry, s.options)
+	return slices.Clone(s.entries), nil
+}
+
+func (s *MemoryHistoryStore) Update(entry HistoryEntry) ([]HistoryEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	replaceHistoryEntry(s.entries, entry)
+	return slices.Clone(s.entries), nil
+}
+
+func (s *MemoryHistoryStore) Load() ([]HistoryEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.entries), nil
+}
+
+func (s *MemoryHistoryStore) Search(query HistoryQuery) ([]HistoryEntry, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return query.Filter(s.entries), nil
+}
+
+func (s *MemoryHistoryStore) Clear() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = nil
+	return nil
+}
+
+// Adds the entry following the dedupe policy and the size limit. It returns
+// whether the entry was added and whether older entries were removed.
+func addHistoryEntry(entries []HistoryEntry, entry HistoryEntry, options HistoryOptions) ([]HistoryEntry, bool, bool) {
+	if options.Dedupe == IgnoreDuplicates && len(entries) > 0 && entries[len(entries)-1].Command == entry.Command {
+		return entries, false, false
+	}
+
+	isDuplicate := func(e HistoryEntry) bool {
+		return e.Command == entry.Command
+	}
+
+	removed := false
+	if options.Dedupe == EraseDuplicates && slices.ContainsFunc(entries, isDuplicate) {
+		entries = slices.DeleteFunc(slices.Clone(entries), isDuplicate)
+		removed = true
+	}
+
+	entries = append(entries, entry)
+	if options.MaxEntries > 0 && len(entries) > options.MaxEntries {
+		entries = limitHistory(entries, options.MaxEntries)
+		removed = true
+	}
+
+	return entries, true, removed
+}
+
+// Replaces the most recent entry with the same Time and Command, returning
+// whether it was found
+func replaceHistoryEntry(entries []HistoryEntry, entry HistoryEntry) bool {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Command == entry.Command && entries[i].Time.Equal(entry.Time) {
+			entries[i] = entry
+			return true
+		}
+	}
+	return false
+}
+
+// Keeps the most recent entries
+func limitHistory(entries []HistoryEntry, maxEntries int) []HistoryEntry {
+	if maxEntries > 0 && len(entries) > maxEntries {
+		return entries[len(entries)-maxEntries:]
+	}
+	return entries
+}
@@ -0,0 +1,180 @@
+package gocli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+// FileHistoryStore keeps the history in a file, one JSON entry per line. Several
+// sessions may share the file, as it is locked while written.
+type FileHistoryStore struct {
+	options HistoryOptions
+}
+
+// NewFileHistoryStore creates a store for the File of the options, applying the
+// MaxEntries and Dedupe options
+func NewFileHistoryStore(options HistoryOptions) *FileHistoryStore {
+	return &FileHistoryStore{options: options}
+}
+
+// Append writes the entry, rewriting the file when older entries are removed
+func (s *FileHistoryStore) Append(entry HistoryEntry) ([]HistoryEntry, error) {
+	file, err := s.openLocked(os.O_CREATE)
+	if err != nil {
+		return nil, err
+	}
+	defer closeLocked(file)
+
+	entries, err := readHistoryEntries(file)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, added, removed := addHistoryEntry(entries, entry, s.options)
+	if !added {
+		return entries, nil
+	}
+
+	if removed {
+		return entries, writeHistoryEntries(file, entries)
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// A file edited by hand may not end with a new line
+	line := string(encoded) + "\n"
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			line = "\n" + line
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	_, err = file.WriteString(line)
+	return entries, err
+}
+
+// Update rewrites the file when the entry is found
+func (s *FileHistoryStore) Update(entry HistoryEntry) ([]HistoryEntry, error) {
+	file, err := s.openLocked(os.O_CREATE)
+	if err != nil {
+		return nil, err
+	}
+	defer closeLocked(file)
+
+	entries, err := readHistoryEntries(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !replaceHistoryEntry(entries, entry) {
+		return entries, nil
+	}
+	return entries, writeHistoryEntries(file, entries)
+}
+
+// Load returns no entries when the file doesn't exist yet. The file is locked
+// too, so it is not read while another session rewrites it.
+func (s *FileHistoryStore) Load() ([]HistoryEntry, error) {
+	file, err := s.openLocked(0)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closeLocked(file)
+
+	entries, err := readHistoryEntries(file)
+	if err != nil {
+		return nil, err
+	}
+	return limitHistory(entries, s.options.MaxEntries), nil
+}
+
+func (s *FileHistoryStore) Search(query HistoryQuery) ([]HistoryEntry, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return query.Filter(entries), nil
+}
+
+func (s *FileHistoryStore) Clear() error {
+	file, err := s.openLocked(os.O_CREATE)
+	if err != nil {
+		return err
+	}
+	defer closeLocked(file)
+
+	return file.Truncate(0)
+}
+
+// Opens the file for writing, as unix systems only give exclusive locks to
+// writable files, with the given flags like os.O_CREATE
+func (s *FileHistoryStore) openLocked(flag int) (*os.File, error) {
+	file, err := os.OpenFile(s.options.File, os.O_RDWR|flag, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := gu.LockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+func closeLocked(file *os.File) {
+	gu.UnlockFile(file)
+	file.Close()
+}
+
+// Replaces the content of the file with the entries
+func writeHistoryEntries(file *os.File, entries []HistoryEntry) error {
+	var content strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		content.Write(line)
+		content.WriteString("\n")
+	}
+	// Writing before truncating keeps the old entries if the write fails
+	if _, err := file.WriteAt([]byte(content.String()), 0); err != nil {
+		return err
+	}
+	return file.Truncate(int64(content.Len()))
+}
+
+// Lines that are not JSON entries, like the ones written by older versions,
+// are read as plain commands
+func readHistoryEntries(file *os.File) ([]HistoryEntry, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	for _, line := range strings.Split(string(content), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &entry) != nil || len(entry.Command) == 0 {
+			entry = HistoryEntry{Command: line}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
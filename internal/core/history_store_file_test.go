@@ -0,0 +1,45 @@
+package gocli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileHistoryStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history")
+	store := NewFileHistoryStore(HistoryOptions{File: file, Dedupe: EraseDuplicates})
+
+	entries, err := store.Load()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v", entries, err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Load() created the file")
+	}
+
+	// Erasing the long duplicate rewrites the file with shorter content
+	for _, command := range []string{"a very long command to be erased", "b", "a very long command to be erased"} {
+		if _, err := store.Append(HistoryEntry{Command: command}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.Append(HistoryEntry{Command: "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Command != "a very long command to be erased" || entries[1].Command != "b" {
+		t.Errorf("Load() = %+v, want the two commands in order", entries)
+	}
+
+	if err := store.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := store.Load(); err != nil || len(entries) != 0 {
+		t.Errorf("Load() after Clear() = %v, %v", entries, err)
+	}
+}
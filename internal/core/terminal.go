@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"regexp"
 	"strings"
 	"syscall"
+	"time"
 
 	gt "github.com/vcharco/gocli/internal/types"
 	gu "github.com/vcharco/gocli/internal/utils"
@@ -19,9 +19,16 @@ type Terminal struct {
 	Commands            []gt.Command
 	BypassCharacter     string
 	CtrlKeys            []byte
+	EditOnError         bool
+	Highlighter         Highlighter
+	InlineSuggestions   bool
+	History             HistoryOptions
 	cursorPos           int
 	startSelection      int
+	promptTime          time.Time
 	commandHistory      *commandHistory
+	historyStore        HistoryStore
+	lastHistoryEntry    *HistoryEntry
 	autoCompletionLines int
 }
 
@@ -30,6 +37,11 @@ type TerminalStyles struct {
 	PromptColor            gu.Color
 	ForegroundColor        gu.Color
 	ForegroundSuggestions  gu.Color
+	CommandForeground      gu.Color
+	ParamForeground        gu.Color
+	ValueForeground        gu.Color
+	QuotedForeground       gu.Color
+	InvalidForeground      gu.Color
 	BackgroundColor        gu.BgColor
 	SelForegroundColor     gu.Color
 	SelBackgroundColor     gu.BgColor
@@ -39,6 +51,7 @@ type TerminalStyles struct {
 	HelpParamsForeground   gu.Color
 	HelpRequiredForeground gu.Color
 	HelpLineColor          gu.Color
+	ErrorForeground        gu.Color
 	Cursor                 gu.Cursor
 }
 
@@ -76,9 +89,29 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 		// Enter
 		if len(userInput) > 0 && (input == 10 || input == 13) {
 
+			// Lines starting with a space are kept out of the history with IgnoreSpace
+			recordHistory := !t.History.IgnoreSpace || !strings.HasPrefix(userInput, " ")
+
+			// Expand the references to the history, displaying the resulting line
+			if t.History.Expansion {
+				expanded, changed, err := expandHistory(userInput, t.commandHistory.getCommands())
+				if err != nil && t.EditOnError {
+					t.printValidationError(userInput, err)
+					continue
+				}
+				if err != nil {
+					return getTerminalResponse("", map[string]interface{}{}, userInput, CmdError, 0, err, oldState)
+				}
+				if changed {
+					t.replaceLine(&userInput, expanded)
+				}
+			}
+
 			// Bypass command to OS
 			if len(t.BypassCharacter) > 0 && strings.HasPrefix(userInput, t.BypassCharacter) {
-				t.commandHistory.append(userInput)
+				if recordHistory {
+					t.appendHistory(userInput, OsCmd, nil)
+				}
 				rt := getTerminalResponse("", map[string]interface{}{}, userInput[len(t.BypassCharacter):], OsCmd, 0, nil, oldState)
 				gu.ExecCmd(userInput[len(t.BypassCharacter):])
 				return rt
@@ -89,7 +122,12 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 
 			if strings.HasSuffix(userInput, "?") {
 				userInput = userInput[:len(userInput)-1]
-				command, err := gv.GetClosestCommand(t.Commands, userInput)
+				if len(userInput) == 0 {
+					tr := getTerminalResponse("", map[string]interface{}{}, userInput, CmdHelp, 0, nil, oldState)
+					t.printCommandsHelp()
+					return tr
+				}
+				command, err := gv.GetClosestCommand(t.getCandidateCommands(userInput), userInput)
 				if err != nil {
 					return getTerminalResponse(command.Name, map[string]interface{}{}, userInput, CmdError, 0, err, oldState)
 				}
@@ -98,21 +136,50 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 				return tr
 			}
 
-			// Validate command
-			command, params, err := gv.ValidateCommand(t.Commands, userInput)
+			// Built-in help command, listing the commands when no command is given
+			if name, ok := t.getHelpArgument(userInput); ok {
+				if len(name) == 0 {
+					tr := getTerminalResponse(helpCommandName, map[string]interface{}{}, userInput, CmdHelp, 0, nil, oldState)
+					t.printCommandsHelp()
+					return tr
+				}
+				command, err := gv.GetClosestCommand(t.getCandidateCommands(name), name)
+				if err != nil {
+					return getTerminalResponse(helpCommandName, map[string]interface{}{}, userInput, CmdError, 0, err, oldState)
+				}
+				tr := getTerminalResponse(command.Name, map[string]interface{}{}, userInput, CmdHelp, 0, nil, oldState)
+				t.printHelp(command)
+				return tr
+			}
+
+			// Validate command, resolving prefixes against the same commands as the completion
+			command, parsed, err := gv.ValidateCommand(t.getCandidateCommands(userInput), userInput)
+
+			// Keep the prompt open to fix the input in place
+			if err != nil && t.EditOnError {
+				t.printValidationError(userInput, err)
+				continue
+			}
 
 			// Log command in the history
-			t.commandHistory.append(userInput)
+			if recordHistory && err != nil && !t.History.SkipErrors {
+				t.appendHistory(userInput, ParamError, err)
+			} else if recordHistory && err == nil {
+				t.appendHistory(userInput, Cmd, nil)
+			}
 
 			if err != nil {
 				return getTerminalResponse("", map[string]interface{}{}, userInput, ParamError, 0, err, oldState)
 			}
 
 			// Format line and return
-			re := regexp.MustCompile(`^\S+`)
-			t.replaceLine(&userInput, re.ReplaceAllString(userInput, command.Name))
+			tokens, _ := gu.Tokenize(userInput)
+			t.replaceLine(&userInput, userInput[:tokens[0].Start]+command.Name+userInput[tokens[0].End:])
 
-			return getTerminalResponse(command.Name, params, userInput, Cmd, 0, nil, oldState)
+			tr := getTerminalResponse(command.Name, parsed.Params, userInput, Cmd, 0, nil, oldState)
+			tr.Args = parsed.Args
+			tr.Sources = parsed.Sources
+			return tr
 		}
 
 		// Check special commands and overriden CTRL+KEY
@@ -125,13 +192,8 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 
 		// Autocomplete TAB
 		if input == 9 {
-			bestMatch, _ := gu.BestMatch(userInput, gt.GetCommandNames(t.Commands))
-			if userInput == bestMatch {
-				t.printAutocompleteSuggestions(userInput)
+			if !t.autocomplete(&userInput) {
 				continue
-			} else {
-				userInput = bestMatch
-				t.cursorPos = len(bestMatch)
 			}
 		}
 
@@ -143,8 +205,8 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 			}
 		}
 
-		// Handle cursor movement and text selection
-		if !t.handleCursorAndContinue(input, buf, &userInput) {
+		// Handle cursor movement and text selection, unless an inline suggestion is accepted
+		if !t.acceptInlineSuggestion(input, buf, &userInput) && !t.handleCursorAndContinue(input, buf, &userInput) {
 			continue
 		}
 
@@ -158,15 +220,16 @@ func (t *Terminal) Get(data ...string) TerminalResponse {
 		t.CleanNextLines(t.autoCompletionLines)
 		t.autoCompletionLines = 1
 		t.CleanCurrentLine()
-		output := fmt.Sprint(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, userInput))
+		output := t.highlight(userInput)
 
 		// Apply highlight to selected text
 		if highlighted, ok := t.highlightSelected(userInput); ok {
 			output = highlighted
 		}
 
-		// Print the line
+		// Print the line and the suggestion to complete it
 		fmt.Print(output)
+		t.printInlineSuggestion(userInput)
 
 		// Set the cursor position at the right place
 		t.moveCursorToPos(t.cursorPos)
@@ -3,16 +3,125 @@ package gocli
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	gt "github.com/vcharco/gocli/internal/types"
+	gu "github.com/vcharco/gocli/internal/utils"
+	gv "github.com/vcharco/gocli/internal/validation"
 	"golang.org/x/term"
 )
 
-func (t *Terminal) printAutocompleteSuggestions(userInput string) {
+// Completes the word under the cursor. It returns false when there was nothing
+// to complete and the suggestions were printed instead.
+func (t *Terminal) autocomplete(userInput *string) bool {
+	word, start, candidates, suggestions := t.getCompletionCandidates((*userInput)[:t.cursorPos])
+
+	bestMatch, _ := gu.BestMatch(word, candidates)
+	if word == bestMatch {
+		t.printAutocompleteSuggestions(suggestions)
+		return false
+	}
+
+	replacement := gu.Quote(bestMatch)
+	*userInput = (*userInput)[:start] + replacement + (*userInput)[t.cursorPos:]
+	t.cursorPos = start + len(replacement)
+	return true
+}
+
+// Returns the word being completed, the offset where it starts, the values that
+// may complete it and the ones to be displayed as suggestions.
+func (t *Terminal) getCompletionCandidates(line string) (string, int, []string, []string) {
+	tokens, _ := gu.Tokenize(line)
+
+	word := ""
+	start := len(line)
+	if len(tokens) > 0 && tokens[len(tokens)-1].End == len(line) {
+		word = tokens[len(tokens)-1].Value
+		start = tokens[len(tokens)-1].Start
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	if len(tokens) == 0 {
+		return word, start, gt.GetCommandNames(t.getCandidateCommands(word)), t.filterCommands(word)
+	}
+
+	command, err := gv.GetClosestCommand(t.getCandidateCommands(tokens[0].Value), tokens[0].Value)
+	if err != nil {
+		return word, start, nil, nil
+	}
+
+	candidates := getParamCompletions(command, gu.TokenValues(tokens[1:]), word)
+	return word, start, candidates, filterPrefix(candidates, word)
+}
+
+// Completes the value of the previous param, the value given as --name=value, a
+// param name or the value of the next positional param.
+func getParamCompletions(command gt.Command, previous []string, word string) []string {
+	if name, value, found := strings.Cut(word, "="); found && strings.HasPrefix(word, "--") {
+		param, ok := gv.FindParam(name, command.Params)
+		if !ok || param.Type == gt.None {
+			return nil
+		}
+		var candidates []string
+		for _, candidate := range gv.GetValueCompletions(param, value) {
+			candidates = append(candidates, name+"="+candidate)
+		}
+		return candidates
+	}
+
+	if len(previous) > 0 {
+		if param, ok := gv.FindParam(previous[len(previous)-1], command.Params); ok && param.Type != gt.None {
+			return gv.GetValueCompletions(param, word)
+		}
+	}
+
+	candidates := gt.GetParamNames(command.Params)
+	if !strings.HasPrefix(word, "-") {
+		if param, ok := getNextPositionalParam(command, previous); ok {
+			candidates = append(candidates, gv.GetValueCompletions(param, word)...)
+		}
+	}
+	return candidates
+}
+
+// Guesses which positional param the next word binds to
+func getNextPositionalParam(command gt.Command, previous []string) (gt.Param, bool) {
+	var positionals []gt.Param
+	for _, param := range command.Params {
+		if gt.IsPositional(param) {
+			positionals = append(positionals, param)
+		}
+	}
+
+	count := 0
+	for i := 0; i < len(previous); i++ {
+		if param, ok := gv.FindParam(previous[i], command.Params); ok {
+			if param.Type != gt.None {
+				i++
+			}
+			continue
+		}
+		if len(previous[i]) > 1 && strings.HasPrefix(previous[i], "-") {
+			continue
+		}
+		count++
+	}
+
+	if count < len(positionals) {
+		return positionals[count], true
+	}
+	if len(positionals) > 0 && gt.IsVariadic(positionals[len(positionals)-1]) {
+		return positionals[len(positionals)-1], true
+	}
+	return gt.Param{}, false
+}
+
+func (t *Terminal) printAutocompleteSuggestions(suggestions []string) {
 	t.CleanNextLines(t.autoCompletionLines)
 	t.cleanNextLineAndStay()
-	adjusted, lines := t.GetAdjustedLine(t.filterCommands(userInput), "    ")
+	adjusted, lines := t.GetAdjustedLine(suggestions, "    ")
 	if lines < 1 {
 		lines = 1
 	}
@@ -26,8 +135,9 @@ func (t *Terminal) printAutocompleteSuggestions(userInput string) {
 
 func (t *Terminal) filterCommands(prefix string) []string {
 	var result []string
-	gt.SortCommands(t.Commands)
-	for _, candidate := range t.Commands {
+	commands := slices.Clone(t.getCandidateCommands(prefix))
+	gt.SortCommands(commands)
+	for _, candidate := range commands {
 		if strings.HasPrefix(candidate.Name, prefix) && candidate.Name != prefix && !candidate.Hidden {
 			result = append(result, candidate.Name)
 		}
@@ -35,6 +145,17 @@ func (t *Terminal) filterCommands(prefix string) []string {
 	return result
 }
 
+func filterPrefix(candidates []string, prefix string) []string {
+	var result []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && candidate != prefix {
+			result = append(result, candidate)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (t *Terminal) GetAdjustedLine(items []string, separator string) (string, int) {
 	maxLen, _, _ := term.GetSize(int(os.Stdout.Fd()))
 
@@ -26,16 +26,16 @@ func (t *Terminal) handleCursorAndContinue(input byte, buf []byte, userInput *st
 		}
 		// UP
 		if buf[2] == 65 {
-			str, err := t.commandHistory.getPrev(*userInput)
+			str, err := t.commandHistory.getPrev(*userInput, t.getHistoryPrefix(*userInput))
 			if err == nil {
-				t.replaceLine(userInput, str)
+				t.replaceHistoryLine(userInput, str)
 			}
 		}
 		// DOWN
 		if buf[2] == 66 {
 			str, err := t.commandHistory.getNext()
 			if err == nil {
-				t.replaceLine(userInput, str)
+				t.replaceHistoryLine(userInput, str)
 			}
 		}
 
@@ -61,6 +61,25 @@ func (t *Terminal) handleCursorAndContinue(input byte, buf []byte, userInput *st
 	return true
 }
 
+// With PrefixSearch, the text before the cursor filters the history
+func (t *Terminal) getHistoryPrefix(userInput string) string {
+	if !t.History.PrefixSearch {
+		return ""
+	}
+	return userInput[:t.cursorPos]
+}
+
+// Replaces the line with a command of the history. When it is filtered by a
+// prefix, the cursor stays at the end of the prefix.
+func (t *Terminal) replaceHistoryLine(userInput *string, command string) {
+	cursorPos := t.cursorPos
+	t.replaceLine(userInput, command)
+	if len(t.commandHistory.Prefix) > 0 && cursorPos <= len(command) {
+		t.cursorPos = cursorPos
+		t.moveCursorToPos(t.cursorPos)
+	}
+}
+
 // This must be executed after Clipboard validation, else Clipboard Copy (CTRL+C) always be empty
 func (t *Terminal) checkTextSelection(input byte, buf []byte, userInput *string) {
 	if input == 27 && len(buf) >= 3 && buf[1] == 91 {
@@ -3,6 +3,8 @@ package gocli
 import (
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"strings"
 
 	gt "github.com/vcharco/gocli/internal/types"
@@ -10,66 +12,172 @@ import (
 	gv "github.com/vcharco/gocli/internal/validation"
 )
 
-func (t *Terminal) printHelp(command gt.Command) {
+type helpSection struct {
+	title string
+	text  string
+	items []string
+}
+
+const helpCommandName = "help"
+
+// The built-in help command is replaced by a declared one with the same name
+func (t *Terminal) isBuiltinHelp() bool {
+	return !slices.ContainsFunc(t.Commands, func(command gt.Command) bool {
+		return command.Name == helpCommandName
+	})
+}
+
+// Returns the declared commands plus the built-in help command
+func (t *Terminal) getCommands() []gt.Command {
+	if !t.isBuiltinHelp() {
+		return t.Commands
+	}
+
+	var names []string
+	for _, command := range t.Commands {
+		if !command.Hidden {
+			names = append(names, command.Name)
+		}
+	}
+
+	return append(slices.Clone(t.Commands), gt.Command{
+		Name:        helpCommandName,
+		Description: "Displays the available commands or the help of a command",
+		Params: []gt.Param{
+			{Name: "command", Type: gt.Choice, Choices: names, Modifier: gt.DEFAULT, Description: "Command to display the help of"},
+		},
+	})
+}
+
+// Returns the commands the first word of the line is resolved against. The
+// declared commands come first, so the built-in help is only included when the
+// word is help or it is not the prefix of any declared command. This way he
+// still runs hello, and hel runs help when no declared command starts with it.
+func (t *Terminal) getCandidateCommands(line string) []gt.Command {
+	tokens, _ := gu.Tokenize(line)
+	if len(tokens) == 0 || tokens[0].Value == helpCommandName {
+		return t.getCommands()
+	}
+	if slices.ContainsFunc(t.Commands, func(command gt.Command) bool {
+		return strings.HasPrefix(command.Name, tokens[0].Value)
+	}) {
+		return t.Commands
+	}
+	return t.getCommands()
+}
+
+// Returns the text after the built-in help command, or false when the line
+// doesn't run it. The command is resolved like the rest, so a prefix like hel
+// runs it too when no declared command starts with it.
+func (t *Terminal) getHelpArgument(line string) (string, bool) {
+	if !t.isBuiltinHelp() {
+		return "", false
+	}
+	tokens, _ := gu.Tokenize(line)
+	if len(tokens) == 0 {
+		return "", false
+	}
+	command, err := gv.GetClosestCommand(t.getCandidateCommands(line), tokens[0].Value)
+	if err != nil || command.Name != helpCommandName {
+		return "", false
+	}
+	return strings.TrimSpace(line[tokens[0].End:]), true
+}
+
+// Prints the commands that are not hidden, grouped by category
+func (t *Terminal) printCommandsHelp() {
+	categories := map[string][]gt.Command{}
+	largestNameLen := 0
+	for _, command := range t.getCommands() {
+		if command.Hidden {
+			continue
+		}
+		categories[command.Category] = append(categories[command.Category], command)
+		largestNameLen = max(largestNameLen, len(command.Name))
+	}
+
+	var names []string
+	for category := range categories {
+		names = append(names, category)
+	}
+	sort.Strings(names)
+
+	// Commands without category go first
+	var sections []helpSection
+	for _, category := range names {
+		commands := categories[category]
+		gt.SortCommands(commands)
+
+		section := helpSection{title: strings.ToUpper(category)}
+		if len(category) == 0 {
+			section.title = "COMMANDS"
+		}
+		for _, command := range commands {
+			formattedName := fmt.Sprintf("%-*v", largestNameLen, command.Name)
+			section.items = append(section.items, fmt.Sprintf("%v  %v", gu.ColorizeForeground(t.Styles.HelpParamsForeground, formattedName), gu.ColorizeForeground(t.Styles.HelpTextForeground, command.Description)))
+		}
+		sections = append(sections, section)
+	}
 
-	gt.SortParams(command.Params)
+	t.printHelpHeader("HELP")
+	t.printHelpSections(sections)
 
+	fmt.Println()
+}
+
+func (t *Terminal) printHelp(command gt.Command) {
+
+	var positionalParams []gt.Param
 	var commandFlags []gt.Param
 	var commandParams []gt.Param
-	var defaultParam gt.Param
 	largestParamNameLen := 0
 	largestFlagNameLen := 0
+	largestPositionalLen := 0
 
+	// Positional params keep the declaration order, the rest are sorted by name
 	for _, param := range command.Params {
-		if param.Modifier&gt.DEFAULT != 0 {
-			defaultParam = param
+		if gt.IsPositional(param) {
+			positionalParams = append(positionalParams, param)
+			paramLen := len(getPositionalUsage(param))
+			if largestPositionalLen < paramLen {
+				largestPositionalLen = paramLen
+			}
+		}
+	}
+
+	sortedParams := make([]gt.Param, len(command.Params))
+	copy(sortedParams, command.Params)
+	gt.SortParams(sortedParams)
+
+	for _, param := range sortedParams {
+		if gt.IsPositional(param) {
 			continue
 		}
 		if param.Type == gt.None {
 			commandFlags = append(commandFlags, param)
-			paramLen := len(param.Name)
+			paramLen := len(getParamUsage(param))
 			if largestFlagNameLen < paramLen {
 				largestFlagNameLen = paramLen
 			}
 			continue
 		}
 		commandParams = append(commandParams, param)
-		paramLen := len(param.Name) + len(gv.GetValidationTypeName(param.Type))
+		paramLen := len(getParamUsage(param))
 		if largestParamNameLen < paramLen {
 			largestParamNameLen = paramLen
 		}
 	}
 
-	vs := gu.ColorizeForeground(t.Styles.HelpLineColor, "│")
-	hs := gu.ColorizeForeground(t.Styles.HelpLineColor, "─")
-	tc := gu.ColorizeForeground(t.Styles.HelpLineColor, "┬")
-	tl := gu.ColorizeForeground(t.Styles.HelpLineColor, "┌")
-	tr := gu.ColorizeForeground(t.Styles.HelpLineColor, "┐")
-	bl := gu.ColorizeForeground(t.Styles.HelpLineColor, "└")
-	br := gu.ColorizeForeground(t.Styles.HelpLineColor, "┘")
-	lc := gu.ColorizeForeground(t.Styles.HelpLineColor, "├")
-
-	var prefix string
-
-	prefix = strings.Repeat(hs, int(math.Max(0, float64(len(command.Name)))))
-	fmt.Printf("\n%v%v%v%v%v\n%v %v %v\n%v%v%v%v%v\n", tl, hs, hs, prefix, tr, vs, gu.ColorizeForeground(t.Styles.HelpCommandForeground, command.Name), vs, bl, hs, tc, prefix, br)
+	var sections []helpSection
 
 	if len(command.Description) > 0 {
-		if len(defaultParam.Name) > 0 || len(commandFlags) > 0 || len(commandParams) > 0 {
-			prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, hs)
-		} else {
-			prefix = fmt.Sprintf("  %v\n  %v%v ", vs, bl, hs)
-		}
-		fmt.Printf("%v%v%v\n", prefix, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, "DESCRIPTION  "), gu.ColorizeForeground(t.Styles.HelpTextForeground, command.Description))
+		sections = append(sections, helpSection{title: "DESCRIPTION  ", text: gu.ColorizeForeground(t.Styles.HelpTextForeground, command.Description)})
 	}
 
-	if len(defaultParam.Name) > 0 || len(commandFlags) > 0 || len(commandParams) > 0 {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, hs)
-	} else {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, bl, hs)
+	if len(command.Category) > 0 {
+		sections = append(sections, helpSection{title: "CATEGORY  ", text: gu.ColorizeForeground(t.Styles.HelpTextForeground, command.Category)})
 	}
 
-	usageLine := fmt.Sprintf("%v%v%v", prefix, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, "USAGE  "), command.Name)
 	usageLineValue := ""
 
 	if len(commandFlags) > 0 {
@@ -80,82 +188,225 @@ func (t *Terminal) printHelp(command gt.Command) {
 		usageLineValue += " [PARAMS]"
 	}
 
-	if len(defaultParam.Name) > 0 {
-		if defaultParam.Modifier&gt.REQUIRED != 0 {
-			usageLineValue += fmt.Sprintf(" <%v>", gv.GetValidationTypeName(defaultParam.Type))
+	for _, group := range command.Groups {
+		if groupUsage := getGroupUsage(group, command.Params); len(groupUsage) > 0 {
+			usageLineValue += " " + groupUsage
+		}
+	}
+
+	for _, param := range positionalParams {
+		if param.Modifier&gt.REQUIRED != 0 {
+			usageLineValue += " " + getPositionalUsage(param)
 		} else {
-			usageLineValue += fmt.Sprintf(" [<%v>]", gv.GetValidationTypeName(defaultParam.Type))
+			usageLineValue += fmt.Sprintf(" [%v]", getPositionalUsage(param))
 		}
 	}
 
-	fmt.Printf("%v\n", usageLine+gu.ColorizeForeground(t.Styles.HelpTextForeground, usageLineValue))
+	sections = append(sections, helpSection{title: "USAGE  ", text: command.Name + gu.ColorizeForeground(t.Styles.HelpTextForeground, usageLineValue)})
 
-	if len(commandFlags) > 0 || len(commandParams) > 0 {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, hs)
-	} else {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, bl)
+	if len(positionalParams) > 0 {
+		section := helpSection{title: "ARGUMENTS"}
+		for _, param := range positionalParams {
+			formattedParamName := fmt.Sprintf("%-*v", largestPositionalLen, getPositionalUsage(param))
+			section.items = append(section.items, t.getHelpParamLine(param, formattedParamName))
+		}
+		sections = append(sections, section)
 	}
 
-	if len(defaultParam.Description) > 0 || defaultParam.Modifier&gt.REQUIRED != 0 {
-		reqText := ""
-		if defaultParam.Modifier&gt.REQUIRED != 0 {
-			reqText = gu.ColorizeForeground(t.Styles.HelpRequiredForeground, "(REQUIRED) ")
+	if len(commandFlags) > 0 {
+		section := helpSection{title: "FLAGS"}
+		for _, param := range commandFlags {
+			formattedParamName := fmt.Sprintf("%-*v", largestFlagNameLen, getParamUsage(param))
+			section.items = append(section.items, t.getHelpParamLine(param, formattedParamName))
 		}
-		fmt.Printf("%v%v%v%v\n", prefix, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, "DEFAULT PARAM  "), reqText, gu.ColorizeForeground(t.Styles.HelpTextForeground, defaultParam.Description))
+		sections = append(sections, section)
 	}
 
 	if len(commandParams) > 0 {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, hs)
-	} else {
-		prefix = fmt.Sprintf("  %v\n  %v%v ", vs, lc, bl)
+		section := helpSection{title: "PARAMS"}
+		for _, param := range commandParams {
+			paramValue := fmt.Sprintf("%-*v", largestParamNameLen, getParamUsage(param))
+			section.items = append(section.items, t.getHelpParamLine(param, paramValue))
+		}
+		sections = append(sections, section)
 	}
 
-	if len(commandFlags) > 0 {
-		fmt.Printf("%v%v\n", prefix, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, "FLAGS"))
+	if len(command.Examples) > 0 {
+		section := helpSection{title: "EXAMPLES"}
+		for _, example := range command.Examples {
+			section.items = append(section.items, gu.ColorizeForeground(t.Styles.HelpTextForeground, example))
+		}
+		sections = append(sections, section)
 	}
 
-	for i, param := range commandFlags {
-		if i < len(commandFlags)-1 {
-			if len(commandParams) > 0 {
-				prefix = fmt.Sprintf("  %v   %v\n  %v   %v%v ", vs, vs, vs, lc, hs)
-			} else {
-				prefix = fmt.Sprintf("      %v\n      %v%v ", vs, bl, hs)
-			}
-		} else {
-			if len(commandParams) > 0 {
-				prefix = fmt.Sprintf("  %v   %v\n  %v   %v%v ", vs, vs, vs, bl, hs)
-			} else {
-				prefix = fmt.Sprintf("      %v\n      %v%v ", vs, bl, hs)
-			}
+	t.printHelpHeader(command.Name)
+	t.printHelpSections(sections)
+
+	fmt.Println()
+}
+
+func (t *Terminal) getHelpParamLine(param gt.Param, formattedName string) string {
+	reqText := ""
+	if param.Modifier&gt.REQUIRED != 0 {
+		reqText = gu.ColorizeForeground(t.Styles.HelpRequiredForeground, " (REQUIRED)")
+	}
+	for _, text := range []string{getCountText(param), getRangeText(param), getLengthText(param)} {
+		if len(text) > 0 {
+			reqText += gu.ColorizeForeground(t.Styles.HelpTextForeground, " "+text)
 		}
-		reqText := ""
-		if param.Modifier&gt.REQUIRED != 0 {
-			reqText = gu.ColorizeForeground(t.Styles.HelpRequiredForeground, " (REQUIRED)")
+	}
+	if len(param.Pattern) > 0 {
+		reqText += gu.ColorizeForeground(t.Styles.HelpTextForeground, fmt.Sprintf(" (matches %v)", param.Pattern))
+	}
+	if len(param.EnvVar) > 0 && !gt.IsPositional(param) {
+		reqText += gu.ColorizeForeground(t.Styles.HelpTextForeground, fmt.Sprintf(" (env: %v)", param.EnvVar))
+	}
+	if param.Default != nil && !gt.IsPositional(param) {
+		reqText += gu.ColorizeForeground(t.Styles.HelpTextForeground, fmt.Sprintf(" (default: %v)", param.Default))
+	}
+	return fmt.Sprintf("%v %v %v", gu.ColorizeForeground(t.Styles.HelpParamsForeground, formattedName), reqText, gu.ColorizeForeground(t.Styles.HelpTextForeground, param.Description))
+}
+
+func (t *Terminal) printHelpHeader(title string) {
+	vs := gu.ColorizeForeground(t.Styles.HelpLineColor, "│")
+	hs := gu.ColorizeForeground(t.Styles.HelpLineColor, "─")
+	tc := gu.ColorizeForeground(t.Styles.HelpLineColor, "┬")
+	tl := gu.ColorizeForeground(t.Styles.HelpLineColor, "┌")
+	tr := gu.ColorizeForeground(t.Styles.HelpLineColor, "┐")
+	bl := gu.ColorizeForeground(t.Styles.HelpLineColor, "└")
+	br := gu.ColorizeForeground(t.Styles.HelpLineColor, "┘")
+
+	prefix := strings.Repeat(hs, int(math.Max(0, float64(len(title)))))
+	fmt.Printf("\n%v%v%v%v%v\n%v %v %v\n%v%v%v%v%v\n", tl, hs, hs, prefix, tr, vs, gu.ColorizeForeground(t.Styles.HelpCommandForeground, title), vs, bl, hs, tc, prefix, br)
+}
+
+// Prints the sections as the branches of a tree hanging from the header
+func (t *Terminal) printHelpSections(sections []helpSection) {
+	vs := gu.ColorizeForeground(t.Styles.HelpLineColor, "│")
+	hs := gu.ColorizeForeground(t.Styles.HelpLineColor, "─")
+	bl := gu.ColorizeForeground(t.Styles.HelpLineColor, "└")
+	lc := gu.ColorizeForeground(t.Styles.HelpLineColor, "├")
+
+	for i, section := range sections {
+		branch, indent := lc, vs
+		if i == len(sections)-1 {
+			branch, indent = bl, " "
+		}
+
+		fmt.Printf("  %v\n  %v%v %v%v\n", vs, branch, hs, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, section.title), section.text)
+
+		for j, item := range section.items {
+			itemBranch := lc
+			if j == len(section.items)-1 {
+				itemBranch = bl
+			}
+			fmt.Printf("  %v   %v\n  %v   %v%v %v\n", indent, vs, indent, itemBranch, hs, item)
 		}
-		formattedParamName := fmt.Sprintf("%-*v", largestFlagNameLen, param.Name)
-		fmt.Printf("%v %v %v %v\n", prefix, gu.ColorizeForeground(t.Styles.HelpParamsForeground, formattedParamName), reqText, gu.ColorizeForeground(t.Styles.HelpTextForeground, param.Description))
 	}
+}
 
-	prefix = fmt.Sprintf("  %v\n  %v%v ", vs, bl, hs)
+func getParamDisplayName(param gt.Param) string {
+	return strings.Join(append([]string{param.Name}, param.Aliases...), ", ")
+}
 
-	if len(commandParams) > 0 {
-		fmt.Printf("%v%v\n", prefix, gu.ColorizeForeground(t.Styles.HelpTitlesForeground, "PARAMS"))
+// Formats a named param as --name <Type>, adding ... when it can be repeated
+func getParamUsage(param gt.Param) string {
+	usage := getParamDisplayName(param)
+	if param.Type != gt.None {
+		usage += " <" + getTypeUsage(param) + ">"
+	}
+	if param.Modifier&gt.REPEATABLE != 0 {
+		usage += "..."
+	}
+	return usage
+}
+
+// Formats a positional param as <name:Type>, adding ... when variadic
+func getPositionalUsage(param gt.Param) string {
+	usage := param.Name
+	if param.Type != gt.None {
+		usage += ":" + getTypeUsage(param)
 	}
+	usage = "<" + usage + ">"
+	if gt.IsVariadic(param) {
+		usage += "..."
+	}
+	return usage
+}
 
-	for i, param := range commandParams {
-		if i < len(commandParams)-1 {
-			prefix = fmt.Sprintf("      %v\n      %v%v ", vs, lc, hs)
-		} else {
-			prefix = fmt.Sprintf("      %v\n      %v%v ", vs, bl, hs)
+// Formats a group as [--a | --b] when exclusive, (--a | --b) when one is required
+// and [--b [--a]] when --a requires --b
+func getGroupUsage(group gt.ParamGroup, params []gt.Param) string {
+	var names []string
+	for _, name := range group.Params {
+		if param, ok := gv.FindParam(name, params); ok {
+			name = param.Name
 		}
-		reqText := ""
-		if param.Modifier&gt.REQUIRED != 0 {
-			reqText = gu.ColorizeForeground(t.Styles.HelpRequiredForeground, " (REQUIRED)")
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	switch group.Type {
+	case gt.MutuallyExclusive:
+		return "[" + strings.Join(names, " | ") + "]"
+	case gt.AtLeastOne:
+		return "(" + strings.Join(names, " | ") + ")"
+	case gt.Requires:
+		if len(names) == 1 {
+			return ""
 		}
-		paramValue := fmt.Sprintf("%v <%v>", param.Name, gv.GetValidationTypeName(param.Type))
-		paramValue = fmt.Sprintf("%-*v", largestParamNameLen+3, paramValue)
-		fmt.Printf("%v %v %v %v\n", prefix, gu.ColorizeForeground(t.Styles.HelpParamsForeground, paramValue), reqText, gu.ColorizeForeground(t.Styles.HelpTextForeground, param.Description))
+		return fmt.Sprintf("[%v [%v]]", strings.Join(names[1:], " "), names[0])
 	}
+	return ""
+}
 
-	fmt.Println()
+func getTypeUsage(param gt.Param) string {
+	typeName := gv.GetValidationTypeName(param.Type)
+	if param.Type == gt.Choice {
+		typeName = strings.Join(param.Choices, "|")
+	}
+	if param.Modifier&gt.LIST != 0 {
+		typeName += ",..."
+	}
+	return typeName
+}
+
+func getCountText(param gt.Param) string {
+	unit := gt.GetCountUnit(param)
+
+	switch {
+	case param.MinCount > 0 && param.MaxCount > 0:
+		return fmt.Sprintf("(%v to %v %v)", param.MinCount, param.MaxCount, unit)
+	case param.MinCount > 0:
+		return fmt.Sprintf("(at least %v %v)", param.MinCount, unit)
+	case param.MaxCount > 0:
+		return fmt.Sprintf("(at most %v %v)", param.MaxCount, unit)
+	}
+	return ""
+}
+
+func getRangeText(param gt.Param) string {
+	switch {
+	case param.Min != nil && param.Max != nil:
+		return fmt.Sprintf("(%v to %v)", *param.Min, *param.Max)
+	case param.Min != nil:
+		return fmt.Sprintf("(>= %v)", *param.Min)
+	case param.Max != nil:
+		return fmt.Sprintf("(<= %v)", *param.Max)
+	}
+	return ""
+}
+
+func getLengthText(param gt.Param) string {
+	switch {
+	case param.MinLength > 0 && param.MaxLength > 0:
+		return fmt.Sprintf("(%v to %v chars)", param.MinLength, param.MaxLength)
+	case param.MinLength > 0:
+		return fmt.Sprintf("(at least %v chars)", param.MinLength)
+	case param.MaxLength > 0:
+		return fmt.Sprintf("(at most %v chars)", param.MaxLength)
+	}
+	return ""
 }
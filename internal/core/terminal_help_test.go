@@ -0,0 +1,48 @@
+package gocli
+
+import (
+	"reflect"
+	"testing"
+
+	gt "github.com/vcharco/gocli/internal/types"
+	gv "github.com/vcharco/gocli/internal/validation"
+)
+
+func TestBuiltinHelpResolution(t *testing.T) {
+	terminal := &Terminal{Commands: []gt.Command{{Name: "hello"}, {Name: "history"}, {Name: "copy"}}}
+
+	tests := []struct {
+		line    string
+		command string
+		help    bool
+	}{
+		{"he", "hello", false},
+		{"hel x", "hello", false},
+		{"hi", "history", false},
+		{"help", "help", true},
+		{"help copy", "help", true},
+	}
+
+	for _, test := range tests {
+		command, err := gv.GetClosestCommand(terminal.getCandidateCommands(test.line), test.line)
+		if err != nil || command.Name != test.command {
+			t.Errorf("line %q resolved to %q, %v, want %q", test.line, command.Name, err, test.command)
+		}
+		if _, help := terminal.getHelpArgument(test.line); help != test.help {
+			t.Errorf("getHelpArgument(%q) = %v, want %v", test.line, help, test.help)
+		}
+	}
+
+	if commands := terminal.filterCommands("h"); !reflect.DeepEqual(commands, []string{"hello", "history"}) {
+		t.Errorf("filterCommands(\"h\") = %q, want hello and history", commands)
+	}
+
+	// Without a declared command starting with the prefix, it resolves to help
+	terminal.Commands = []gt.Command{{Name: "copy"}}
+	if argument, help := terminal.getHelpArgument("hel copy"); !help || argument != "copy" {
+		t.Errorf("getHelpArgument(\"hel copy\") = %q, %v, want copy", argument, help)
+	}
+	if commands := terminal.filterCommands("h"); !reflect.DeepEqual(commands, []string{"help"}) {
+		t.Errorf("filterCommands(\"h\") = %q, want help", commands)
+	}
+}
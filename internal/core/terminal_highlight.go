@@ -0,0 +1,176 @@
+package gocli
+
+import (
+	"strings"
+
+	gt "github.com/vcharco/gocli/internal/types"
+	gu "github.com/vcharco/gocli/internal/utils"
+	gv "github.com/vcharco/gocli/internal/validation"
+)
+
+// Highlighter colors the input line while it is typed. The returned text must
+// only add color codes, so the cursor stays in place.
+type Highlighter interface {
+	Highlight(line string) string
+}
+
+// HighlighterFunc allows using a function as a Highlighter
+type HighlighterFunc func(line string) string
+
+func (f HighlighterFunc) Highlight(line string) string {
+	return f(line)
+}
+
+// Part of the line colored the same way
+type highlightSpan struct {
+	start int
+	end   int
+	color gu.Color
+}
+
+func (t *Terminal) highlight(line string) string {
+	if t.Highlighter != nil {
+		return t.Highlighter.Highlight(line)
+	}
+
+	var output strings.Builder
+	last := 0
+	for _, span := range t.getHighlightSpans(line) {
+		output.WriteString(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, line[last:span.start]))
+		output.WriteString(gu.ColorizeBoth(span.color, t.Styles.BackgroundColor, line[span.start:span.end]))
+		last = span.end
+	}
+	output.WriteString(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, line[last:]))
+	return output.String()
+}
+
+// Colors the command, the params and their values the way they would be parsed
+func (t *Terminal) getHighlightSpans(line string) []highlightSpan {
+	tokens, _ := gu.Tokenize(line)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// Bypassed commands are not parsed
+	if len(t.BypassCharacter) > 0 && strings.HasPrefix(line, t.BypassCharacter) {
+		return nil
+	}
+
+	command, err := gv.GetClosestCommand(t.getCandidateCommands(tokens[0].Value), tokens[0].Value)
+	if err != nil {
+		color := t.Styles.InvalidForeground
+		if t.isCommandPrefix(tokens[0].Value) {
+			color = t.Styles.ForegroundColor
+		}
+		return []highlightSpan{{start: tokens[0].Start, end: tokens[0].End, color: color}}
+	}
+
+	spans := []highlightSpan{{start: tokens[0].Start, end: tokens[0].End, color: t.Styles.CommandForeground}}
+
+	var positionals []gt.Param
+	for _, param := range command.Params {
+		if gt.IsPositional(param) {
+			positionals = append(positionals, param)
+		}
+	}
+
+	var pending *gt.Param
+	endOfOptions := false
+	for _, token := range tokens[1:] {
+		word := token.Value
+		name, value, hasValue := strings.Cut(word, "=")
+
+		if pending != nil {
+			spans = append(spans, t.getValueSpan(*pending, token, token.Start, word))
+			pending = nil
+			continue
+		}
+
+		if !endOfOptions {
+			if word == "--" {
+				endOfOptions = true
+				spans = append(spans, highlightSpan{start: token.Start, end: token.End, color: t.Styles.ParamForeground})
+				continue
+			}
+
+			if param, ok := gv.FindParam(word, command.Params); ok {
+				spans = append(spans, highlightSpan{start: token.Start, end: token.End, color: t.Styles.ParamForeground})
+				if param.Type != gt.None {
+					pending = &param
+				}
+				continue
+			}
+
+			if param, ok := gv.FindParam(name, command.Params); ok && hasValue && strings.HasPrefix(word, "--") && param.Type != gt.None {
+				valueStart := token.Start + len(name) + 1
+				spans = append(spans, highlightSpan{start: token.Start, end: valueStart, color: t.Styles.ParamForeground})
+				spans = append(spans, t.getValueSpan(param, token, valueStart, value))
+				continue
+			}
+
+			if valued, ok := getShortParamsValue(word, command.Params); ok {
+				spans = append(spans, highlightSpan{start: token.Start, end: token.End, color: t.Styles.ParamForeground})
+				pending = valued
+				continue
+			}
+
+			// Unknown params, unless they are negative numbers given to a positional param
+			if gv.LooksLikeParam(word) {
+				spans = append(spans, highlightSpan{start: token.Start, end: token.End, color: t.Styles.InvalidForeground})
+				continue
+			}
+		}
+
+		if len(positionals) == 0 {
+			spans = append(spans, highlightSpan{start: token.Start, end: token.End, color: t.Styles.InvalidForeground})
+			continue
+		}
+		spans = append(spans, t.getValueSpan(positionals[0], token, token.Start, word))
+		if !gt.IsVariadic(positionals[0]) {
+			positionals = positionals[1:]
+		}
+	}
+
+	return spans
+}
+
+func (t *Terminal) getValueSpan(param gt.Param, token gu.Token, start int, value string) highlightSpan {
+	span := highlightSpan{start: start, end: token.End, color: t.Styles.ValueForeground}
+	if gv.ValidateValue(param, value) != nil {
+		span.color = t.Styles.InvalidForeground
+	} else if token.Quoted {
+		span.color = t.Styles.QuotedForeground
+	}
+	return span
+}
+
+// Tells if a word is made of bundled short params (-abc). When the last one needs
+// a value not included in the word, it is returned.
+func getShortParamsValue(word string, params []gt.Param) (*gt.Param, bool) {
+	if len(word) < 3 || word[0] != '-' || word[1] == '-' {
+		return nil, false
+	}
+	for i := 1; i < len(word); i++ {
+		param, ok := gv.FindParam("-"+word[i:i+1], params)
+		if !ok {
+			return nil, false
+		}
+		if param.Type != gt.None {
+			if i == len(word)-1 {
+				return &param, true
+			}
+			return nil, true
+		}
+	}
+	return nil, true
+}
+
+// While the command is being typed it isn't marked as invalid
+func (t *Terminal) isCommandPrefix(word string) bool {
+	for _, command := range t.getCandidateCommands(word) {
+		if strings.HasPrefix(command.Name, word) {
+			return true
+		}
+	}
+	return false
+}
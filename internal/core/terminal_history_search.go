@@ -0,0 +1,120 @@
+package gocli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	gt "github.com/vcharco/gocli/internal/types"
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+// State of the reverse incremental search of the history
+type historySearch struct {
+	query  string
+	index  int
+	failed bool
+}
+
+// Searches the history backwards as the query is typed. CTRL+R looks for older
+// matches, ENTER (or any cursor key) accepts the match and ESC or CTRL+G restore
+// the line typed before the search.
+func (t *Terminal) reverseSearch(userInput *string) {
+	original := *userInput
+	commands := t.commandHistory.getCommands()
+	search := historySearch{index: len(commands)}
+	match := ""
+
+	for {
+		t.printHistorySearch(search, match)
+
+		buf := make([]byte, 6)
+		if _, err := os.Stdin.Read(buf); err != nil {
+			t.endHistorySearch(userInput, original)
+			return
+		}
+
+		input := buf[0]
+		switch {
+		// Cancel
+		case (input == 27 && buf[1] == 0) || input == gt.Ctrl_G:
+			t.endHistorySearch(userInput, original)
+			return
+		// Older match
+		case input == gt.Ctrl_R:
+			if index, ok := findHistoryMatch(commands, search.query, search.index-1); ok {
+				search.index = index
+				match = commands[index]
+				search.failed = false
+			} else {
+				search.failed = true
+			}
+		// Backspace, searching again from the most recent command
+		case input == 127:
+			if len(search.query) > 0 {
+				search.query = search.query[:len(search.query)-1]
+			}
+			search.index = len(commands)
+			search.failed = false
+			match = ""
+			if index, ok := findHistoryMatch(commands, search.query, len(commands)-1); ok && len(search.query) > 0 {
+				search.index = index
+				match = commands[index]
+			}
+		// Type the query, keeping the current match while it still matches
+		case input >= 32 && input < 127:
+			search.query += string(input)
+			if index, ok := findHistoryMatch(commands, search.query, min(search.index, len(commands)-1)); ok {
+				search.index = index
+				match = commands[index]
+				search.failed = false
+			} else {
+				search.failed = true
+			}
+		// Accept
+		default:
+			if len(match) == 0 {
+				match = original
+			}
+			t.endHistorySearch(userInput, match)
+			return
+		}
+	}
+}
+
+// Returns the most recent command containing the query, starting at the index
+func findHistoryMatch(commands []string, query string, from int) (int, bool) {
+	for i := from; i >= 0; i-- {
+		if strings.Contains(commands[i], query) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (t *Terminal) printHistorySearch(search historySearch, match string) {
+	label := "(reverse-i-search)"
+	if search.failed {
+		label = "(failed reverse-i-search)"
+	}
+	label = fmt.Sprintf("%v'%v': ", label, search.query)
+
+	fmt.Print("\033[1G\033[K")
+	fmt.Print(gu.ColorizeForeground(t.Styles.PromptColor, label))
+	fmt.Print(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, match))
+
+	// The cursor is placed at the matched text
+	offset := len(match)
+	if i := strings.LastIndex(match, search.query); i != -1 && len(search.query) > 0 {
+		offset = i
+	}
+	fmt.Printf("\033[%dG", len(label)+offset+1)
+}
+
+// Restores the prompt with the given line
+func (t *Terminal) endHistorySearch(userInput *string, line string) {
+	fmt.Print("\033[1G\033[K")
+	t.printPrompt()
+	t.replaceLine(userInput, line)
+	t.commandHistory.resetIndex()
+}
@@ -1,11 +1,15 @@
 package gocli
 
 import (
+	"time"
+
 	gu "github.com/vcharco/gocli/internal/utils"
 )
 
 func (t *Terminal) init() {
 	t.cursorPos = 0
+	t.promptTime = time.Now()
+	t.lastHistoryEntry = nil
 	t.startSelection = -1
 	t.autoCompletionLines = 1
 	if len(t.Styles.Prompt) == 0 {
@@ -20,6 +24,21 @@ func (t *Terminal) init() {
 	if len(t.Styles.ForegroundSuggestions) == 0 {
 		t.Styles.ForegroundSuggestions = gu.LightGray
 	}
+	if len(t.Styles.CommandForeground) == 0 {
+		t.Styles.CommandForeground = gu.Green
+	}
+	if len(t.Styles.ParamForeground) == 0 {
+		t.Styles.ParamForeground = gu.Cyan
+	}
+	if len(t.Styles.ValueForeground) == 0 {
+		t.Styles.ValueForeground = t.Styles.ForegroundColor
+	}
+	if len(t.Styles.QuotedForeground) == 0 {
+		t.Styles.QuotedForeground = gu.Yellow
+	}
+	if len(t.Styles.InvalidForeground) == 0 {
+		t.Styles.InvalidForeground = gu.Red
+	}
 	if len(t.Styles.BackgroundColor) == 0 {
 		t.Styles.BackgroundColor = gu.BgTransparent
 	}
@@ -47,8 +66,13 @@ func (t *Terminal) init() {
 	if len(t.Styles.HelpLineColor) == 0 {
 		t.Styles.HelpLineColor = gu.Blue
 	}
+	if len(t.Styles.ErrorForeground) == 0 {
+		t.Styles.ErrorForeground = gu.Red
+	}
 	if t.commandHistory == nil {
-		t.commandHistory = &commandHistory{Commands: []string{}, CurrentIndex: 0, Cache: "", IsCacheActive: false}
+		t.commandHistory = &commandHistory{Entries: []HistoryEntry{}, CurrentIndex: 0, Cache: "", IsCacheActive: false}
+		t.historyStore = getHistoryStore(t.History)
+		t.loadHistory()
 	}
 	if t.Styles.Cursor == "" {
 		t.Styles.Cursor = gu.CursorBlock
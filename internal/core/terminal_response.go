@@ -3,7 +3,10 @@ package gocli
 import (
 	"fmt"
 	"os"
+	"time"
 
+	gt "github.com/vcharco/gocli/internal/types"
+	gv "github.com/vcharco/gocli/internal/validation"
 	"golang.org/x/term"
 )
 
@@ -22,6 +25,8 @@ const (
 type TerminalResponse struct {
 	Command  string
 	Params   map[string]interface{}
+	Args     []interface{}
+	Sources  map[string]gt.ParamSource
 	RawInput string
 	Type     TerminalResponseType
 	CtrlKey  byte
@@ -30,14 +35,84 @@ type TerminalResponse struct {
 
 func (tr *TerminalResponse) GetParam(name string, defaultValue interface{}) interface{} {
 	if value, exists := tr.Params[name]; exists {
-		if val, ok := value.(string); ok && len(val) == 0 {
-			return true
-		}
 		return value
 	}
 	return defaultValue
 }
 
+// Has tells if the param was given, even if its value is the zero value of its type
+func (tr *TerminalResponse) Has(name string) bool {
+	_, exists := tr.Params[name]
+	return exists
+}
+
+// Source tells if the value of the param was typed, taken from its environment
+// variable or its default value
+func (tr *TerminalResponse) Source(name string) (gt.ParamSource, bool) {
+	source, exists := tr.Sources[name]
+	return source, exists
+}
+
+func (tr *TerminalResponse) GetString(name string) (string, bool) {
+	return ParamAs[string](tr, name)
+}
+
+// GetInt also accepts the int64 values of the Size type
+func (tr *TerminalResponse) GetInt(name string) (int, bool) {
+	switch value := tr.Params[name].(type) {
+	case int:
+		return value, true
+	case int64:
+		return int(value), true
+	}
+	return 0, false
+}
+
+// GetFloat also accepts integer values
+func (tr *TerminalResponse) GetFloat(name string) (float64, bool) {
+	switch value := tr.Params[name].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+func (tr *TerminalResponse) GetBool(name string) (bool, bool) {
+	return ParamAs[bool](tr, name)
+}
+
+func (tr *TerminalResponse) GetDuration(name string) (time.Duration, bool) {
+	return ParamAs[time.Duration](tr, name)
+}
+
+// GetStrings returns the values of a multi-valued param. A single string value
+// is returned as a slice with one item.
+func (tr *TerminalResponse) GetStrings(name string) ([]string, bool) {
+	switch value := tr.Params[name].(type) {
+	case []string:
+		return value, true
+	case string:
+		return []string{value}, true
+	}
+	return nil, false
+}
+
+// Bind copies the params into the fields of the struct v points to, matching
+// them by the gocli struct tag
+func (tr *TerminalResponse) Bind(v interface{}) error {
+	return gv.BindParams(tr.Params, v)
+}
+
+// ParamAs returns the value of the param when it was given and its type is T
+func ParamAs[T any](tr *TerminalResponse, name string) (T, bool) {
+	value, ok := tr.Params[name].(T)
+	return value, ok
+}
+
 func getTerminalResponse(command string, params map[string]interface{}, rawInput string, responseType TerminalResponseType, ctrlKey byte, err error, oldState *term.State) TerminalResponse {
 	if oldState != nil {
 		term.Restore(int(os.Stdin.Fd()), oldState)
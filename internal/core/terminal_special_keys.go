@@ -39,6 +39,9 @@ func (t *Terminal) checkSpecialKeys(input byte, userInput *string, oldState *ter
 		// Move cursor at the end of the line
 		case gt.Ctrl_E:
 			t.cursorPos = len(*userInput)
+		// Search the history
+		case gt.Ctrl_R:
+			t.reverseSearch(userInput)
 		}
 	}
 
@@ -0,0 +1,76 @@
+package gocli
+
+import (
+	"fmt"
+	"strings"
+
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+// Returns the text that would complete the line, taken from the most recent
+// command of the history starting with it or, when there is none, from the best
+// completion of the last word
+func (t *Terminal) getInlineSuggestion(userInput string) string {
+	if !t.InlineSuggestions || len(userInput) == 0 || t.cursorPos != len(userInput) {
+		return ""
+	}
+
+	for i := len(t.commandHistory.Entries) - 1; i >= 0; i-- {
+		command := t.commandHistory.Entries[i].Command
+		if len(command) > len(userInput) && strings.HasPrefix(command, userInput) {
+			return command[len(userInput):]
+		}
+	}
+
+	word, start, candidates, _ := t.getCompletionCandidates(userInput)
+	if len(word) == 0 || userInput[start:] != word {
+		return ""
+	}
+
+	bestMatch, _ := gu.BestMatch(word, candidates)
+	replacement := gu.Quote(bestMatch)
+	if len(replacement) > len(word) && strings.HasPrefix(replacement, word) {
+		return replacement[len(word):]
+	}
+	return ""
+}
+
+func (t *Terminal) printInlineSuggestion(userInput string) {
+	if t.startSelection != -1 {
+		return
+	}
+	if suggestion := t.getInlineSuggestion(userInput); len(suggestion) > 0 {
+		fmt.Print(gu.ColorizeForeground(t.Styles.ForegroundSuggestions, suggestion))
+	}
+}
+
+// Accepts the whole suggestion with RIGHT or END, and its next word with ALT+F.
+// It returns false when the key doesn't accept the suggestion.
+func (t *Terminal) acceptInlineSuggestion(input byte, buf []byte, userInput *string) bool {
+	if input != 27 {
+		return false
+	}
+
+	right := buf[1] == 91 && buf[2] == 67 && buf[3] == 0
+	end := (buf[1] == 91 || buf[1] == 79) && buf[2] == 70
+	nextWord := buf[1] == 'f' && buf[2] == 0
+	if !right && !end && !nextWord {
+		return false
+	}
+
+	suggestion := t.getInlineSuggestion(*userInput)
+	if len(suggestion) == 0 {
+		return false
+	}
+
+	if nextWord {
+		wordStart := len(suggestion) - len(strings.TrimLeft(suggestion, " "))
+		if wordEnd := strings.Index(suggestion[wordStart:], " "); wordEnd != -1 {
+			suggestion = suggestion[:wordStart+wordEnd]
+		}
+	}
+
+	*userInput += suggestion
+	t.cursorPos = len(*userInput)
+	return true
+}
@@ -6,7 +6,7 @@ import (
 
 func (t *Terminal) replaceLine(userInput *string, text string) {
 	t.CleanCurrentLine()
-	fmt.Print(text)
+	fmt.Print(t.highlight(text))
 	t.moveCursorToPos(len(text))
 	t.cursorPos = len(text)
 	*userInput = text
@@ -0,0 +1,34 @@
+package gocli
+
+import (
+	"errors"
+	"fmt"
+
+	gu "github.com/vcharco/gocli/internal/utils"
+	gv "github.com/vcharco/gocli/internal/validation"
+)
+
+// Redraws the line underlining the token that failed, prints the error below it
+// and leaves the cursor at the end of that token so it can be fixed
+func (t *Terminal) printValidationError(userInput string, err error) {
+	start, end := len(userInput), len(userInput)
+	var validationErr *gv.ValidationError
+	if errors.As(err, &validationErr) && validationErr.Offset >= 0 && validationErr.Offset+validationErr.Length <= len(userInput) {
+		start, end = validationErr.Offset, validationErr.Offset+validationErr.Length
+	}
+
+	t.startSelection = -1
+	t.CleanNextLines(t.autoCompletionLines)
+	t.CleanCurrentLine()
+	fmt.Print(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, userInput[:start]))
+	fmt.Print(gu.ColorizeUnderline(t.Styles.ErrorForeground, userInput[start:end]))
+	fmt.Print(gu.ColorizeBoth(t.Styles.ForegroundColor, t.Styles.BackgroundColor, userInput[end:]))
+
+	t.cleanNextLineAndStay()
+	fmt.Print(gu.ColorizeForeground(t.Styles.ErrorForeground, err.Error()))
+	fmt.Print("\033[1A")
+	t.autoCompletionLines = 1
+
+	t.cursorPos = end
+	t.moveCursorToPos(t.cursorPos)
+}
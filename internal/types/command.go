@@ -5,11 +5,33 @@ import "sort"
 type Command struct {
 	Name        string
 	Description string
+	// Commands with the same category are listed together in the help
+	Category string
+	// Lines displayed in the help of the command
+	Examples    []string
 	Hidden      bool
 	Params      []Param
+	Groups      []ParamGroup
 	SubCommands []Command
 }
 
+type ParamGroupType int
+
+const (
+	// None of the params may be used together
+	MutuallyExclusive ParamGroupType = iota
+	// At least one of the params must be used
+	AtLeastOne
+	// The first param can only be used along with all the others
+	Requires
+)
+
+// ParamGroup is a rule between several params of a command, referenced by name
+type ParamGroup struct {
+	Type   ParamGroupType
+	Params []string
+}
+
 func SortCommands(candidates []Command) {
 	sort.Slice(candidates, func(i, j int) bool {
 		return candidates[i].Name < candidates[j].Name
@@ -18,6 +18,17 @@ const (
 	Time
 	Url
 	UUID
+	Choice
+	Duration
+	Bool
+	Path
+	CIDR
+	Port
+	Hex
+	Size
+	DateTime
+	Regex
+	JSON
 )
 
 type ParamModifier int
@@ -25,17 +36,86 @@ type ParamModifier int
 const (
 	DEFAULT ParamModifier = 1 << iota
 	REQUIRED
+	VARIADIC
+	REPEATABLE
+	LIST
 )
 
 type Param struct {
 	Name        string
+	Aliases     []string
 	Description string
 	Modifier    ParamModifier
 	Type        ParamType
+	Choices     []string
+	MinCount    int
+	MaxCount    int
+	Min         *float64
+	Max         *float64
+	MinLength   int
+	MaxLength   int
+	Pattern     string
+	Default     interface{}
+	EnvVar      string
 }
 
+// Where the value of a param was taken from
+type ParamSource int
+
+const (
+	FromInput ParamSource = iota
+	FromEnv
+	FromDefault
+)
+
 func SortParams(candidates []Param) {
 	sort.Slice(candidates, func(i, j int) bool {
 		return candidates[i].Name < candidates[j].Name
 	})
 }
+
+func GetParamNames(params []Param) []string {
+	paramNames := make([]string, 0, len(params))
+	for _, param := range params {
+		if !IsPositional(param) {
+			paramNames = append(paramNames, param.Name)
+			paramNames = append(paramNames, param.Aliases...)
+		}
+	}
+	return paramNames
+}
+
+// Params with the DEFAULT modifier are bound by position instead of by name
+func IsPositional(param Param) bool {
+	return param.Modifier&(DEFAULT|VARIADIC) != 0
+}
+
+func IsVariadic(param Param) bool {
+	return param.Modifier&VARIADIC != 0
+}
+
+// Multi-valued params are returned as a slice of their type
+func IsMultiValued(param Param) bool {
+	if param.Type == None && !IsPositional(param) {
+		return false
+	}
+	return param.Modifier&(VARIADIC|REPEATABLE|LIST) != 0
+}
+
+// Unit used when talking about MinCount and MaxCount
+func GetCountUnit(param Param) string {
+	if param.Modifier&(LIST|VARIADIC) != 0 {
+		return "values"
+	}
+	return "times"
+}
+
+// Named params may take their value from an environment variable or a default
+func HasFallback(param Param) bool {
+	return !IsPositional(param) && (len(param.EnvVar) > 0 || param.Default != nil)
+}
+
+// Bound returns a pointer to the value, to be used in Param.Min and Param.Max
+func Bound(value float64) *float64 {
+	return &value
+}
@@ -81,3 +81,7 @@ func ColorizeBackground(c Color, text string) string {
 func ColorizeBoth(c Color, bc BgColor, text string) string {
 	return string(bc) + string(c) + text + string(Reset)
 }
+
+func ColorizeUnderline(c Color, text string) string {
+	return "\033[4m" + string(c) + text + string(Reset)
+}
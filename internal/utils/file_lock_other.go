@@ -0,0 +1,14 @@
+//go:build !unix && !windows
+
+package gocliutils
+
+import "os"
+
+// Files are not locked in the systems without support for it
+func LockFile(file *os.File) error {
+	return nil
+}
+
+func UnlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,26 @@
+//go:build unix
+
+package gocliutils
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// LockFile blocks until it gets an exclusive lock of the file. It uses fcntl
+// record locks, which are available in every unix system.
+func LockFile(file *os.File) error {
+	return fcntlLock(file, unix.F_WRLCK)
+}
+
+func UnlockFile(file *os.File) error {
+	return fcntlLock(file, unix.F_UNLCK)
+}
+
+// Locks the whole file, as a zero length reaches its end however it grows
+func fcntlLock(file *os.File, lockType int16) error {
+	lock := unix.Flock_t{Type: lockType, Whence: io.SeekStart, Start: 0, Len: 0}
+	return unix.FcntlFlock(file.Fd(), unix.F_SETLKW, &lock)
+}
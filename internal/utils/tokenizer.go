@@ -0,0 +1,121 @@
+package gocliutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Token is a word of the user input after removing quotes and escapes.
+// Start and End are byte offsets of the raw word inside the input line.
+type Token struct {
+	Value  string
+	Start  int
+	End    int
+	Quoted bool
+}
+
+type TokenizeError struct {
+	Message string
+	Pos     int
+}
+
+func (e *TokenizeError) Error() string {
+	return fmt.Sprintf("%v at position %v", e.Message, e.Pos)
+}
+
+// Tokenize splits the input the way a POSIX shell does: words are separated by
+// whitespace, single quotes preserve everything literally, double quotes allow
+// backslash escapes of '"' and '\', and a backslash outside quotes escapes the
+// next character. On error the tokens read so far are returned too, including
+// the unterminated one, so callers like autocompletion can work on partial input.
+func Tokenize(input string) ([]Token, error) {
+	var tokens []Token
+	var current strings.Builder
+	var err error
+
+	inToken := false
+	quoted := false
+	start := 0
+	var quote byte
+	quoteStart := 0
+
+	flush := func(end int) {
+		if inToken {
+			tokens = append(tokens, Token{Value: current.String(), Start: start, End: end, Quoted: quoted})
+		}
+		current.Reset()
+		inToken = false
+		quoted = false
+	}
+
+	begin := func(pos int) {
+		if !inToken {
+			inToken = true
+			start = pos
+		}
+	}
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+				continue
+			}
+			if quote == '"' && c == '\\' && i+1 < len(input) && (input[i+1] == '"' || input[i+1] == '\\') {
+				i++
+				c = input[i]
+			}
+			current.WriteByte(c)
+			continue
+		}
+
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			flush(i)
+		case '\'', '"':
+			begin(i)
+			quote = c
+			quoteStart = i
+			quoted = true
+		case '\\':
+			begin(i)
+			if i+1 >= len(input) {
+				err = &TokenizeError{Message: "dangling escape character", Pos: i}
+				continue
+			}
+			i++
+			current.WriteByte(input[i])
+		default:
+			begin(i)
+			current.WriteByte(c)
+		}
+	}
+
+	if quote != 0 {
+		err = &TokenizeError{Message: fmt.Sprintf("unterminated quote %c", quote), Pos: quoteStart}
+	}
+
+	flush(len(input))
+
+	return tokens, err
+}
+
+// TokenValues returns the plain values of the tokens.
+func TokenValues(tokens []Token) []string {
+	values := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		values = append(values, token.Value)
+	}
+	return values
+}
+
+// Quote returns the value ready to be inserted in the input line, quoting it
+// only when Tokenize would otherwise split or alter it.
+func Quote(value string) string {
+	if len(value) > 0 && !strings.ContainsAny(value, " \t\n\r'\"\\") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,91 @@
+package gocliutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input  string
+		values []string
+	}{
+		{"", []string{}},
+		{"   ", []string{}},
+		{"copy a b", []string{"copy", "a", "b"}},
+		{"  copy\ta  ", []string{"copy", "a"}},
+		{`echo 'a b' "c d"`, []string{"echo", "a b", "c d"}},
+		{`echo 'a\b'`, []string{"echo", `a\b`}},
+		{`echo "a \"b\" \\ \n"`, []string{"echo", `a "b" \ \n`}},
+		{`echo a\ b`, []string{"echo", "a b"}},
+		{`echo ab'c d'e`, []string{"echo", "abc de"}},
+		{`echo ''`, []string{"echo", ""}},
+		{`--name='John Doe'`, []string{"--name=John Doe"}},
+	}
+
+	for _, test := range tests {
+		tokens, err := Tokenize(test.input)
+		if err != nil {
+			t.Errorf("Tokenize(%q) returned error %v", test.input, err)
+			continue
+		}
+		if values := TokenValues(tokens); !reflect.DeepEqual(values, test.values) {
+			t.Errorf("Tokenize(%q) = %q, want %q", test.input, values, test.values)
+		}
+	}
+}
+
+func TestTokenizeOffsets(t *testing.T) {
+	input := `cp 'a b' c\ d`
+	tokens, err := Tokenize(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Token{
+		{Value: "cp", Start: 0, End: 2},
+		{Value: "a b", Start: 3, End: 8, Quoted: true},
+		{Value: "c d", Start: 9, End: 13},
+	}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("Tokenize(%q) = %+v, want %+v", input, tokens, want)
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		pos    int
+		values []string
+	}{
+		{`echo 'abc`, 5, []string{"echo", "abc"}},
+		{`echo "a b`, 5, []string{"echo", "a b"}},
+		{`echo abc\`, 8, []string{"echo", "abc"}},
+	}
+
+	for _, test := range tests {
+		tokens, err := Tokenize(test.input)
+		var tokenizeErr *TokenizeError
+		if !errors.As(err, &tokenizeErr) {
+			t.Errorf("Tokenize(%q) returned error %v, want a TokenizeError", test.input, err)
+			continue
+		}
+		if tokenizeErr.Pos != test.pos {
+			t.Errorf("Tokenize(%q) error at %v, want %v", test.input, tokenizeErr.Pos, test.pos)
+		}
+		// The partial input is kept for autocompletion
+		if values := TokenValues(tokens); !reflect.DeepEqual(values, test.values) {
+			t.Errorf("Tokenize(%q) = %q, want %q", test.input, values, test.values)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, value := range []string{"abc", "a b", "", "it's", `a\b`, `"x"`} {
+		tokens, err := Tokenize(Quote(value))
+		if err != nil || len(tokens) != 1 || tokens[0].Value != value {
+			t.Errorf("Tokenize(Quote(%q)) = %+v, %v", value, tokens, err)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package goclivalidation
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gt "github.com/vcharco/gocli/internal/types"
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+var (
+	emailRegexp  = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	domainRegexp = regexp.MustCompile(`^(?:[a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}$`)
+	uuidRegexp   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89ab][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
+)
+
+func init() {
+	setTypeDefinition(gt.None, TypeDefinition{
+		Name: "None",
+		Convert: func(param gt.Param, value string) (interface{}, error) {
+			return true, nil
+		},
+	})
+
+	setTypeDefinition(gt.Text, TypeDefinition{
+		Name: "Text",
+		Validate: func(param gt.Param, value string) error {
+			if value == "" {
+				return fmt.Errorf("text cannot be empty")
+			}
+			return nil
+		},
+	})
+
+	setTypeDefinition(gt.Number, TypeDefinition{
+		Name: "Number",
+		Validate: func(param gt.Param, value string) error {
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("parameter %v must be a number", param.Name)
+			}
+			return nil
+		},
+		Convert: func(param gt.Param, value string) (interface{}, error) {
+			toInt, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.New("error when casting the Numeric param to an Integer")
+			}
+			return toInt, nil
+		},
+	})
+
+	setTypeDefinition(gt.FloatNumber, TypeDefinition{
+		Name: "FloatNumber",
+		Validate: func(param gt.Param, value string) error {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("parameter %v must be a float number", param.Name)
+			}
+			return nil
+		},
+		Convert: func(param gt.Param, value string) (interface{}, error) {
+			toFloat, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, errors.New("error when casting the Numeric param to a Float")
+			}
+			return toFloat, nil
+		},
+	})
+
+	setTypeDefinition(gt.Ipv4, parserType("Ipv4", "an IPv4", func(value string) (interface{}, error) {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			return nil, errors.New("not an IPv4")
+		}
+		return addr, nil
+	}))
+
+	setTypeDefinition(gt.Ipv6, parserType("Ipv6", "an IPv6", func(value string) (interface{}, error) {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() {
+			return nil, errors.New("not an IPv6")
+		}
+		return addr, nil
+	}))
+
+	setTypeDefinition(gt.Email, regexpType("Email", emailRegexp, "an email address"))
+	setTypeDefinition(gt.Domain, regexpType("Domain", domainRegexp, "a domain name"))
+
+	setTypeDefinition(gt.Phone, parserType("Phone", "a phone number", func(value string) (interface{}, error) {
+		return normalizePhone(value)
+	}))
+
+	setTypeDefinition(gt.Date, parserType("Date", "a date (YYYY-MM-DD)", func(value string) (interface{}, error) {
+		return time.Parse(time.DateOnly, value)
+	}))
+
+	setTypeDefinition(gt.Time, parserType("Time", "a time (HH:mm)", func(value string) (interface{}, error) {
+		// The layout accepts hours with a single digit
+		if len(value) != len("15:04") {
+			return nil, errors.New("not a time")
+		}
+		return time.Parse("15:04", value)
+	}))
+
+	setTypeDefinition(gt.Url, parserType("Url", "a URL", func(value string) (interface{}, error) {
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Hostname()) == 0 {
+			return nil, errors.New("not an http(s) URL")
+		}
+		return parsed, nil
+	}))
+
+	setTypeDefinition(gt.UUID, regexpType("UUID", uuidRegexp, "a UUID (v4)"))
+
+	setTypeDefinition(gt.Choice, TypeDefinition{
+		Name: "Choice",
+		Validate: func(param gt.Param, value string) error {
+			if !slices.Contains(param.Choices, value) {
+				return fmt.Errorf("parameter %v must be one of: %v", param.Name, strings.Join(param.Choices, ", "))
+			}
+			return nil
+		},
+		Complete: func(param gt.Param, prefix string) []string {
+			return param.Choices
+		},
+	})
+
+	setTypeDefinition(gt.Duration, parserType("Duration", "a duration (Ej: 30s, 5m, 1h30m)", func(value string) (interface{}, error) {
+		return time.ParseDuration(value)
+	}))
+
+	boolType := parserType("Bool", "a boolean (true/false, yes/no, on/off)", func(value string) (interface{}, error) {
+		switch strings.ToLower(value) {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		return strconv.ParseBool(value)
+	})
+	boolType.Complete = func(param gt.Param, prefix string) []string {
+		return []string{"true", "false"}
+	}
+	setTypeDefinition(gt.Bool, boolType)
+
+	setTypeDefinition(gt.Path, TypeDefinition{
+		Name: "Path",
+		Validate: func(param gt.Param, value string) error {
+			if value == "" {
+				return fmt.Errorf("parameter %v must be a path", param.Name)
+			}
+			return nil
+		},
+		Convert: func(param gt.Param, value string) (interface{}, error) {
+			return expandHome(value), nil
+		},
+		Complete: func(param gt.Param, prefix string) []string {
+			return gu.GetPathCompletions(prefix)
+		},
+	})
+
+	setTypeDefinition(gt.CIDR, parserType("CIDR", "a CIDR (Ej: 10.0.0.0/8)", func(value string) (interface{}, error) {
+		return netip.ParsePrefix(value)
+	}))
+
+	setTypeDefinition(gt.Port, parserType("Port", "a port (1-65535)", func(value string) (interface{}, error) {
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, errors.New("not a port")
+		}
+		return port, nil
+	}))
+
+	setTypeDefinition(gt.Hex, parserType("Hex", "a hexadecimal string", func(value string) (interface{}, error) {
+		value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		if value == "" {
+			return nil, errors.New("empty hexadecimal string")
+		}
+		return hex.DecodeString(value)
+	}))
+
+	setTypeDefinition(gt.Size, parserType("Size", "a size (Ej: 512, 10KB, 512MiB)", func(value string) (interface{}, error) {
+		return parseSize(value)
+	}))
+
+	setTypeDefinition(gt.DateTime, parserType("DateTime", "a date and time (RFC 3339)", func(value string) (interface{}, error) {
+		return time.Parse(time.RFC3339, value)
+	}))
+
+	setTypeDefinition(gt.Regex, parserType("Regex", "a regular expression", func(value string) (interface{}, error) {
+		return regexp.Compile(value)
+	}))
+
+	setTypeDefinition(gt.JSON, parserType("JSON", "a JSON document", func(value string) (interface{}, error) {
+		if !json.Valid([]byte(value)) {
+			return nil, errors.New("invalid JSON")
+		}
+		return json.RawMessage(value), nil
+	}))
+}
+
+func regexpType(name string, re *regexp.Regexp, description string) TypeDefinition {
+	return TypeDefinition{
+		Name: name,
+		Validate: func(param gt.Param, value string) error {
+			if !re.MatchString(value) {
+				return fmt.Errorf("parameter %v must be %v", param.Name, description)
+			}
+			return nil
+		},
+	}
+}
+
+// Types validated by parsing the value, which is also the converted value
+func parserType(name string, description string, parse func(value string) (interface{}, error)) TypeDefinition {
+	return TypeDefinition{
+		Name: name,
+		Validate: func(param gt.Param, value string) error {
+			if _, err := parse(value); err != nil {
+				return fmt.Errorf("parameter %v must be %v", param.Name, description)
+			}
+			return nil
+		},
+		Convert: func(param gt.Param, value string) (interface{}, error) {
+			return parse(value)
+		},
+	}
+}
+
+// Accepts an optional leading +, digits and single separators between them (space,
+// dash, dot or a parenthesized group). It returns the number without separators,
+// which must have between 7 and 15 digits (E.164).
+func normalizePhone(value string) (string, error) {
+	invalid := errors.New("not a phone number")
+
+	var normalized strings.Builder
+	digits := 0
+	inGroup := false
+	lastSeparator := true
+
+	for i, c := range value {
+		switch {
+		case c == '+' && i == 0:
+			normalized.WriteRune(c)
+		case c >= '0' && c <= '9':
+			normalized.WriteRune(c)
+			digits++
+			lastSeparator = false
+		case c == ' ' || c == '-' || c == '.':
+			if lastSeparator || inGroup {
+				return "", invalid
+			}
+			lastSeparator = true
+		case c == '(':
+			if inGroup {
+				return "", invalid
+			}
+			inGroup = true
+			lastSeparator = true
+		case c == ')':
+			if !inGroup || lastSeparator {
+				return "", invalid
+			}
+			inGroup = false
+		default:
+			return "", invalid
+		}
+	}
+
+	if inGroup || lastSeparator || digits < 7 || digits > 15 {
+		return "", invalid
+	}
+
+	return normalized.String(), nil
+}
+
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// Parses sizes like 512, 1.5GB or 512MiB into bytes. Units without "i" are decimal
+// (1KB = 1000 bytes) and the ones with "i" are binary (1KiB = 1024 bytes).
+func parseSize(value string) (int64, error) {
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i == -1 {
+		i = len(value)
+	}
+
+	if i == 0 {
+		return 0, errors.New("invalid size")
+	}
+
+	multiplier, ok := sizeUnits[strings.ToLower(strings.TrimSpace(value[i:]))]
+	if !ok {
+		return 0, errors.New("invalid size unit")
+	}
+
+	// Whole numbers are parsed as integers, so large sizes keep their precision
+	if !strings.Contains(value[:i], ".") {
+		number, err := strconv.ParseInt(value[:i], 10, 64)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && number > math.MaxInt64/int64(multiplier)) {
+			return 0, errors.New("size too large")
+		}
+		if err != nil {
+			return 0, errors.New("invalid size")
+		}
+		return number * int64(multiplier), nil
+	}
+
+	number, err := strconv.ParseFloat(value[:i], 64)
+	if err != nil || number < 0 {
+		return 0, errors.New("invalid size")
+	}
+
+	// MaxInt64 rounds up to 2^63 as a float, which doesn't fit in an int64
+	size := number * multiplier
+	if size >= math.MaxInt64 {
+		return 0, errors.New("size too large")
+	}
+
+	return int64(size), nil
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,156 @@
+package goclivalidation
+
+import (
+	"math"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+func TestBuiltinValidators(t *testing.T) {
+	tests := []struct {
+		paramType gt.ParamType
+		value     string
+		ok        bool
+	}{
+		{gt.Ipv4, "192.168.1.1", true},
+		{gt.Ipv4, "0.0.0.0", true},
+		{gt.Ipv4, "999.999.999.999", false},
+		{gt.Ipv4, "1.2.3", false},
+		{gt.Ipv4, "01.2.3.4", false},
+		{gt.Ipv4, "::1", false},
+		{gt.Ipv4, "x192.168.1.1", false},
+		{gt.Ipv6, "::1", true},
+		{gt.Ipv6, "fe80::1:2", true},
+		{gt.Ipv6, "2001:db8:0:0:0:0:2:1", true},
+		{gt.Ipv6, "2001:db8::g", false},
+		{gt.Ipv6, "x::1", false},
+		{gt.Ipv6, "1.2.3.4", false},
+		{gt.Url, "https://example.com/a?b=c#d", true},
+		{gt.Url, "http://localhost:8080", true},
+		{gt.Url, "ftp://example.com", false},
+		{gt.Url, "https://", false},
+		{gt.Url, "example.com", false},
+		{gt.Date, "2024-02-29", true},
+		{gt.Date, "2023-02-29", false},
+		{gt.Date, "2024-13-45", false},
+		{gt.Date, "2024-1-5", false},
+		{gt.Time, "00:00", true},
+		{gt.Time, "23:59", true},
+		{gt.Time, "24:00", false},
+		{gt.Time, "9:30", false},
+		{gt.Time, "12:60", false},
+		{gt.Phone, "+34 600 123 456", true},
+		{gt.Phone, "(555) 123-4567", true},
+		{gt.Phone, "555.123.4567", true},
+		{gt.Phone, "123", false},
+		{gt.Phone, "555--1234567", false},
+		{gt.Phone, "(555 1234567", false},
+		{gt.Phone, "555-123-456a", false},
+	}
+
+	for _, test := range tests {
+		param := gt.Param{Name: "--p", Type: test.paramType}
+		if err := ValidateType(param, test.value); test.ok != (err == nil) {
+			t.Errorf("ValidateType(%v, %q) returned error %v", GetValidationTypeName(test.paramType), test.value, err)
+		}
+	}
+}
+
+func TestBuiltinConversions(t *testing.T) {
+	tests := []struct {
+		paramType gt.ParamType
+		value     string
+		want      interface{}
+	}{
+		{gt.Ipv4, "10.0.0.1", netip.MustParseAddr("10.0.0.1")},
+		{gt.Ipv6, "::1", netip.MustParseAddr("::1")},
+		{gt.Url, "https://example.com/a", &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}},
+		{gt.Date, "2024-02-29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{gt.Time, "13:45", time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)},
+		{gt.Phone, "+34 (600) 123-456", "+34600123456"},
+	}
+
+	for _, test := range tests {
+		got, err := CastParam(gt.Param{Name: "--p", Type: test.paramType}, test.value)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("CastParam(%v, %q) = %#v, %v, want %#v", GetValidationTypeName(test.paramType), test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value string
+		size  int64
+	}{
+		{"512", 512},
+		{"10KB", 10_000},
+		{"10kb", 10_000},
+		{"1.5GB", 1_500_000_000},
+		{"512MiB", 512 << 20},
+		{"8191PiB", 8191 << 50},
+		{"9223372036854775807", math.MaxInt64},
+		{"9007199254740993", 9007199254740993},
+	}
+
+	for _, test := range tests {
+		if size, err := parseSize(test.value); err != nil || size != test.size {
+			t.Errorf("parseSize(%q) = %v, %v, want %v", test.value, size, err, test.size)
+		}
+	}
+
+	for _, value := range []string{"", "-1", "1.2.3", "12x", "9223372036854775808", "8192PiB", "9999999999PB", "1e30"} {
+		if size, err := parseSize(value); err == nil {
+			t.Errorf("parseSize(%q) = %v, want an error", value, size)
+		}
+	}
+}
+
+func TestAdditionalTypes(t *testing.T) {
+	tests := []struct {
+		paramType gt.ParamType
+		value     string
+		want      interface{}
+	}{
+		{gt.Duration, "1h30m", 90 * time.Minute},
+		{gt.Bool, "yes", true},
+		{gt.Bool, "0", false},
+		{gt.CIDR, "10.0.0.0/8", netip.MustParsePrefix("10.0.0.0/8")},
+		{gt.Port, "443", 443},
+		{gt.Hex, "0aff", []byte{0x0a, 0xff}},
+		{gt.Size, "2KiB", int64(2048)},
+	}
+
+	for _, test := range tests {
+		param := gt.Param{Name: "--p", Type: test.paramType}
+		if err := ValidateType(param, test.value); err != nil {
+			t.Errorf("ValidateType(%v, %q) returned error %v", GetValidationTypeName(test.paramType), test.value, err)
+			continue
+		}
+		if got, err := CastParam(param, test.value); err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("CastParam(%v, %q) = %#v, %v, want %#v", GetValidationTypeName(test.paramType), test.value, got, err, test.want)
+		}
+	}
+
+	invalid := map[gt.ParamType][]string{
+		gt.Duration: {"5", "1x"},
+		gt.Bool:     {"maybe"},
+		gt.CIDR:     {"10.0.0.0/33", "10.0.0.0"},
+		gt.Port:     {"0", "65536", "http"},
+		gt.Hex:      {"", "abc", "zz"},
+		gt.Regex:    {"["},
+		gt.JSON:     {"{", "{'a': 1}"},
+	}
+	for paramType, values := range invalid {
+		for _, value := range values {
+			if err := ValidateType(gt.Param{Name: "--p", Type: paramType}, value); err == nil {
+				t.Errorf("ValidateType(%v, %q) accepted the value", GetValidationTypeName(paramType), value)
+			}
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package goclivalidation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+// Name of the struct tag. Its value is the name of the param followed by options
+// separated by commas: `gocli:"--num,alias=-n,required"`.
+const bindingTag = "gocli"
+
+// Type used for each Go type when the tag doesn't set one with type=Name
+var bindingTypes = map[reflect.Type]gt.ParamType{
+	reflect.TypeOf(""):                gt.Text,
+	reflect.TypeOf(0):                 gt.Number,
+	reflect.TypeOf(int64(0)):          gt.Number,
+	reflect.TypeOf(float64(0)):        gt.FloatNumber,
+	reflect.TypeOf(time.Duration(0)):  gt.Duration,
+	reflect.TypeOf(time.Time{}):       gt.DateTime,
+	reflect.TypeOf(netip.Prefix{}):    gt.CIDR,
+	reflect.TypeOf(&url.URL{}):        gt.Url,
+	reflect.TypeOf(&regexp.Regexp{}):  gt.Regex,
+	reflect.TypeOf(json.RawMessage{}): gt.JSON,
+	reflect.TypeOf([]byte{}):          gt.Hex,
+}
+
+// ParamsFromStruct builds the params of a command from the tagged fields of a struct.
+// Supported options are required, positional, variadic, repeatable, list,
+// alias=-n, type=Name (as registered), choices=a|b|c, min=N, max=N, env=NAME and
+// default=value. The description is taken from the help tag.
+func ParamsFromStruct(v interface{}) ([]gt.Param, error) {
+	structType := reflect.TypeOf(v)
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("params must be declared in a struct")
+	}
+
+	var params []gt.Param
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup(bindingTag)
+		if !ok || tag == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %v must be exported to be binded", field.Name)
+		}
+
+		param, err := getFieldParam(field, tag)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+func getFieldParam(field reflect.StructField, tag string) (gt.Param, error) {
+	options := strings.Split(tag, ",")
+	param := gt.Param{Name: options[0], Description: field.Tag.Get("help")}
+	if len(param.Name) == 0 {
+		return gt.Param{}, fmt.Errorf("field %v has no param name", field.Name)
+	}
+
+	typeSet := false
+	hasDefault := false
+	defaultValue := ""
+	for _, option := range options[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "required":
+			param.Modifier |= gt.REQUIRED
+		case "positional":
+			param.Modifier |= gt.DEFAULT
+		case "variadic":
+			param.Modifier |= gt.VARIADIC
+		case "repeatable":
+			param.Modifier |= gt.REPEATABLE
+		case "list":
+			param.Modifier |= gt.LIST
+		case "env":
+			param.EnvVar = value
+		case "default":
+			defaultValue, hasDefault = value, true
+		case "alias":
+			param.Aliases = append(param.Aliases, strings.Split(value, "|")...)
+		case "choices":
+			param.Type = gt.Choice
+			param.Choices = strings.Split(value, "|")
+			typeSet = true
+		case "type":
+			paramType, ok := GetTypeByName(value)
+			if !ok {
+				return gt.Param{}, fmt.Errorf("field %v has an unknown type %v", field.Name, value)
+			}
+			param.Type = paramType
+			typeSet = true
+		case "min", "max":
+			bound, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return gt.Param{}, fmt.Errorf("field %v has an invalid %v value", field.Name, key)
+			}
+			if key == "min" {
+				param.Min = gt.Bound(bound)
+			} else {
+				param.Max = gt.Bound(bound)
+			}
+		default:
+			return gt.Param{}, fmt.Errorf("field %v has an unknown option %v", field.Name, key)
+		}
+	}
+
+	if !typeSet {
+		paramType, modifier, err := getFieldType(field, param)
+		if err != nil {
+			return gt.Param{}, err
+		}
+		param.Type = paramType
+		param.Modifier |= modifier
+	}
+
+	// The default value is typed as the values typed in the terminal
+	if hasDefault {
+		value, err := parseDefaultValue(param, defaultValue)
+		if err != nil {
+			return gt.Param{}, fmt.Errorf("field %v has an invalid default value: %v", field.Name, err)
+		}
+		param.Default = value
+	}
+
+	return param, nil
+}
+
+// Returns the type of the param from the type of the field, and the modifier
+// slices need to be multi-valued
+func getFieldType(field reflect.StructField, param gt.Param) (gt.ParamType, gt.ParamModifier, error) {
+	fieldType := field.Type
+
+	// Flags, or counters when an int is repeatable
+	if fieldType.Kind() == reflect.Bool {
+		return gt.None, 0, nil
+	}
+	if fieldType.Kind() == reflect.Int && param.Modifier&gt.REPEATABLE != 0 && param.Modifier&gt.LIST == 0 && !gt.IsPositional(param) {
+		return gt.None, 0, nil
+	}
+
+	if paramType, ok := getBindingType(fieldType); ok {
+		return paramType, 0, nil
+	}
+
+	// Slices are multi-valued params of the type of their items
+	if fieldType.Kind() == reflect.Slice {
+		if paramType, ok := getBindingType(fieldType.Elem()); ok {
+			if param.Modifier&(gt.LIST|gt.VARIADIC) == 0 {
+				return paramType, gt.REPEATABLE, nil
+			}
+			return paramType, 0, nil
+		}
+	}
+
+	return gt.None, 0, fmt.Errorf("field %v has a type that needs the type option", field.Name)
+}
+
+func getBindingType(t reflect.Type) (gt.ParamType, bool) {
+	if paramType, ok := bindingTypes[t]; ok {
+		return paramType, true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return gt.Text, true
+	case reflect.Float32, reflect.Float64:
+		return gt.FloatNumber, true
+	}
+	if isNumber(t) {
+		return gt.Number, true
+	}
+
+	return gt.None, false
+}
+
+// BindParams copies the parsed params into the tagged fields of the struct v points to
+func BindParams(parsedParams map[string]interface{}, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("params must be binded to a pointer to a struct")
+	}
+	target = target.Elem()
+
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		tag, ok := field.Tag.Lookup(bindingTag)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		value, exists := parsedParams[name]
+		if !exists {
+			continue
+		}
+
+		if err := setFieldValue(target.Field(i), value); err != nil {
+			return fmt.Errorf("cannot bind parameter %v to field %v: %v", name, field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setFieldValue(field reflect.Value, value interface{}) error {
+	if !field.CanSet() {
+		return fmt.Errorf("field is not exported")
+	}
+
+	source := reflect.ValueOf(value)
+	if !source.IsValid() {
+		return nil
+	}
+
+	if source.Type().AssignableTo(field.Type()) {
+		field.Set(source)
+		return nil
+	}
+
+	// Slices of numbers, like []int into []int64
+	if source.Kind() == reflect.Slice && field.Kind() == reflect.Slice && isNumber(source.Type().Elem()) && isNumber(field.Type().Elem()) {
+		slice := reflect.MakeSlice(field.Type(), source.Len(), source.Len())
+		for i := 0; i < source.Len(); i++ {
+			number, err := convertNumber(source.Index(i), field.Type().Elem())
+			if err != nil {
+				return err
+			}
+			slice.Index(i).Set(number)
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	if isNumber(source.Type()) && isNumber(field.Type()) {
+		number, err := convertNumber(source, field.Type())
+		if err != nil {
+			return err
+		}
+		field.Set(number)
+		return nil
+	}
+
+	return fmt.Errorf("a %T value cannot be assigned to a %v field", value, field.Type())
+}
+
+// Converts between number types, failing when the value doesn't fit in the
+// target type instead of wrapping it around
+func convertNumber(source reflect.Value, target reflect.Type) (reflect.Value, error) {
+	result := reflect.New(target).Elem()
+	overflow := fmt.Errorf("value %v overflows a %v field", source.Interface(), target)
+
+	switch {
+	case result.CanInt():
+		var n int64
+		switch {
+		case source.CanInt():
+			n = source.Int()
+		case source.CanUint():
+			if source.Uint() > math.MaxInt64 {
+				return result, overflow
+			}
+			n = int64(source.Uint())
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) {
+				return result, fmt.Errorf("value %v is not a whole number", f)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return result, overflow
+			}
+			n = int64(f)
+		}
+		if result.OverflowInt(n) {
+			return result, overflow
+		}
+		result.SetInt(n)
+	case result.CanUint():
+		var n uint64
+		switch {
+		case source.CanInt():
+			if source.Int() < 0 {
+				return result, fmt.Errorf("negative value %v cannot be assigned to a %v field", source.Int(), target)
+			}
+			n = uint64(source.Int())
+		case source.CanUint():
+			n = source.Uint()
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) {
+				return result, fmt.Errorf("value %v is not a whole number", f)
+			}
+			if f < 0 {
+				return result, fmt.Errorf("negative value %v cannot be assigned to a %v field", f, target)
+			}
+			if f >= math.MaxUint64 {
+				return result, overflow
+			}
+			n = uint64(f)
+		}
+		if result.OverflowUint(n) {
+			return result, overflow
+		}
+		result.SetUint(n)
+	default:
+		f := source.Convert(reflect.TypeOf(float64(0))).Float()
+		if result.OverflowFloat(f) {
+			return result, overflow
+		}
+		result.SetFloat(f)
+	}
+
+	return result, nil
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
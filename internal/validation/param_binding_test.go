@@ -0,0 +1,111 @@
+package goclivalidation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+type bindingArgs struct {
+	Small int8    `gocli:"--small"`
+	Count uint    `gocli:"--count"`
+	Ratio float32 `gocli:"--ratio"`
+	Ports []uint8 `gocli:"--ports"`
+	Size  int64   `gocli:"--size"`
+}
+
+func TestBindParams(t *testing.T) {
+	var args bindingArgs
+	err := BindParams(map[string]interface{}{"--small": -100, "--count": 5, "--ratio": 1.5, "--ports": []int{80, 255}, "--size": int64(1 << 40)}, &args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bindingArgs{Small: -100, Count: 5, Ratio: 1.5, Ports: []uint8{80, 255}, Size: 1 << 40}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("BindParams = %+v, want %+v", args, want)
+	}
+}
+
+func TestBindParamsOverflow(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"--small": 300},
+		{"--small": -129},
+		{"--count": -1},
+		{"--ratio": 1e300},
+		{"--ports": []int{80, 256}},
+		{"--small": 2.5},
+	}
+
+	for _, params := range tests {
+		var args bindingArgs
+		if err := BindParams(params, &args); err == nil {
+			t.Errorf("BindParams(%v) = %+v, want an error", params, args)
+		}
+	}
+}
+
+type taggedArgs struct {
+	Num     int           `gocli:"--num,alias=-n,required,min=1" help:"Number of foos"`
+	Level   string        `gocli:"--level,choices=debug|info"`
+	Verbose bool          `gocli:"-v"`
+	Tags    []string      `gocli:"--tag"`
+	Timeout time.Duration `gocli:"--timeout,default=30s"`
+	Files   []string      `gocli:"files,positional,variadic"`
+	Ignored string
+}
+
+func TestParamsFromStruct(t *testing.T) {
+	params, err := ParamsFromStruct(&taggedArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	command := []gt.Command{{Name: "cmd", Params: params}}
+	_, parsed, err := ValidateCommand(command, "cmd -n 2 --level info -v --tag a --tag b x y")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var args taggedArgs
+	if err := BindParams(parsed.Params, &args); err != nil {
+		t.Fatal(err)
+	}
+
+	want := taggedArgs{Num: 2, Level: "info", Verbose: true, Tags: []string{"a", "b"}, Timeout: 30 * time.Second, Files: []string{"x", "y"}}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("BindParams = %+v, want %+v", args, want)
+	}
+
+	for _, input := range []string{"cmd x", "cmd -n 0 x", "cmd -n 1 --level warn x"} {
+		if _, _, err := ValidateCommand(command, input); err == nil {
+			t.Errorf("ValidateCommand(%q) accepted the input", input)
+		}
+	}
+}
+
+func TestParamsFromStructErrors(t *testing.T) {
+	tests := []interface{}{
+		struct {
+			A int `gocli:""`
+		}{},
+		struct {
+			A int `gocli:"--a,type=Unknown"`
+		}{},
+		struct {
+			A int `gocli:"--a,min=x"`
+		}{},
+		struct {
+			a int `gocli:"--a"`
+		}{},
+		"not a struct",
+	}
+
+	for _, v := range tests {
+		if _, err := ParamsFromStruct(v); err == nil {
+			t.Errorf("ParamsFromStruct(%T) returned no error", v)
+		}
+	}
+}
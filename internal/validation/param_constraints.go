@@ -0,0 +1,117 @@
+package goclivalidation
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// Patterns are compiled once, as values are validated on every key press to
+// highlight them
+var patternCache = struct {
+	sync.RWMutex
+	patterns map[string]compiledPattern
+}{patterns: map[string]compiledPattern{}}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	patternCache.RLock()
+	compiled, ok := patternCache.patterns[pattern]
+	patternCache.RUnlock()
+	if ok {
+		return compiled.re, compiled.err
+	}
+
+	re, err := regexp.Compile(pattern)
+	patternCache.Lock()
+	patternCache.patterns[pattern] = compiledPattern{re: re, err: err}
+	patternCache.Unlock()
+	return re, err
+}
+
+// Returns the compiled Pattern of the param, failing with an InvalidDefinition
+// error when it is not a valid regular expression
+func getParamPattern(param gt.Param) (*regexp.Regexp, error) {
+	re, err := compilePattern(param.Pattern)
+	if err != nil {
+		return nil, newValidationError(InvalidDefinition, param.Name, "parameter %v has an invalid pattern: %v", param.Name, err)
+	}
+	return re, nil
+}
+
+func checkPatterns(params []gt.Param) error {
+	for _, param := range params {
+		if len(param.Pattern) == 0 {
+			continue
+		}
+		if _, err := getParamPattern(param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checks Min and Max against numeric values, and MinLength, MaxLength and Pattern
+// against the value as it was typed.
+func checkConstraints(param gt.Param, value string, casted interface{}) error {
+	if number, ok := toFloat(casted); ok {
+		if err := checkRange(param, number); err != nil {
+			return err
+		}
+	}
+
+	length := utf8.RuneCountInString(value)
+	if param.MinLength > 0 && param.MaxLength > 0 && (length < param.MinLength || length > param.MaxLength) {
+		return fmt.Errorf("parameter %v must be between %v and %v characters long", param.Name, param.MinLength, param.MaxLength)
+	}
+	if param.MinLength > 0 && length < param.MinLength {
+		return fmt.Errorf("parameter %v must be at least %v characters long", param.Name, param.MinLength)
+	}
+	if param.MaxLength > 0 && length > param.MaxLength {
+		return fmt.Errorf("parameter %v must be at most %v characters long", param.Name, param.MaxLength)
+	}
+
+	if len(param.Pattern) > 0 {
+		re, err := getParamPattern(param)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("parameter %v must match the pattern %v", param.Name, param.Pattern)
+		}
+	}
+
+	return nil
+}
+
+func checkRange(param gt.Param, number float64) error {
+	if param.Min != nil && param.Max != nil && (number < *param.Min || number > *param.Max) {
+		return fmt.Errorf("parameter %v must be between %v and %v", param.Name, *param.Min, *param.Max)
+	}
+	if param.Min != nil && number < *param.Min {
+		return fmt.Errorf("parameter %v must be at least %v", param.Name, *param.Min)
+	}
+	if param.Max != nil && number > *param.Max {
+		return fmt.Errorf("parameter %v must be at most %v", param.Name, *param.Max)
+	}
+	return nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
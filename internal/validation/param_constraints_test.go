@@ -0,0 +1,54 @@
+package goclivalidation
+
+import (
+	"errors"
+	"testing"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+func TestConstraints(t *testing.T) {
+	tests := []struct {
+		param gt.Param
+		value string
+		ok    bool
+	}{
+		{gt.Param{Name: "--n", Type: gt.Number, Min: gt.Bound(1), Max: gt.Bound(10)}, "1", true},
+		{gt.Param{Name: "--n", Type: gt.Number, Min: gt.Bound(1), Max: gt.Bound(10)}, "10", true},
+		{gt.Param{Name: "--n", Type: gt.Number, Min: gt.Bound(1), Max: gt.Bound(10)}, "0", false},
+		{gt.Param{Name: "--n", Type: gt.Number, Min: gt.Bound(1), Max: gt.Bound(10)}, "11", false},
+		{gt.Param{Name: "--f", Type: gt.FloatNumber, Max: gt.Bound(0.5)}, "0.6", false},
+		{gt.Param{Name: "--s", Type: gt.Text, MinLength: 2, MaxLength: 3}, "ab", true},
+		{gt.Param{Name: "--s", Type: gt.Text, MinLength: 2, MaxLength: 3}, "a", false},
+		{gt.Param{Name: "--s", Type: gt.Text, MaxLength: 3}, "ñññ", true},
+		{gt.Param{Name: "--s", Type: gt.Text, MaxLength: 3}, "abcd", false},
+		{gt.Param{Name: "--id", Type: gt.Text, Pattern: "^[a-z]+$"}, "abc", true},
+		{gt.Param{Name: "--id", Type: gt.Text, Pattern: "^[a-z]+$"}, "A1", false},
+	}
+
+	for _, test := range tests {
+		err := ValidateValue(test.param, test.value)
+		var validationErr *ValidationError
+		if test.ok != (err == nil) || (!test.ok && (!errors.As(err, &validationErr) || validationErr.Code != ConstraintViolation)) {
+			t.Errorf("ValidateValue(%v, %q) returned error %v", test.param.Name, test.value, err)
+		}
+	}
+}
+
+func TestPatternDefinition(t *testing.T) {
+	commands := []gt.Command{{Name: "cmd", Params: []gt.Param{
+		{Name: "--id", Type: gt.Text},
+		{Name: "--bad", Type: gt.Text, Pattern: "["},
+	}}}
+
+	_, _, err := ValidateCommand(commands, "cmd --id abc")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Code != InvalidDefinition {
+		t.Errorf("ValidateCommand with an invalid pattern returned error %v, want an InvalidDefinition", err)
+	}
+
+	err = ValidateValue(commands[0].Params[1], "x")
+	if !errors.As(err, &validationErr) || validationErr.Code != InvalidDefinition {
+		t.Errorf("ValidateValue with an invalid pattern returned error %v, want an InvalidDefinition", err)
+	}
+}
@@ -0,0 +1,86 @@
+package goclivalidation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+// Default values satisfy the groups, like they satisfy required params, but
+// they never conflict with other params nor require them
+func checkParamGroups(parsedParams map[string]interface{}, sources map[string]gt.ParamSource, command gt.Command) error {
+	for _, group := range command.Groups {
+		names := getGroupParamNames(group, command.Params)
+
+		var present []string
+		var given []string
+		var missing []string
+		for _, name := range names {
+			if _, exists := parsedParams[name]; !exists {
+				missing = append(missing, name)
+				continue
+			}
+			present = append(present, name)
+			if sources[name] != gt.FromDefault {
+				given = append(given, name)
+			}
+		}
+
+		switch group.Type {
+		case gt.MutuallyExclusive:
+			if len(given) > 1 {
+				return newValidationError(GroupViolation, given[1], "parameters %v cannot be used together", joinNames(given, "and"))
+			}
+		case gt.AtLeastOne:
+			if len(present) == 0 {
+				return newValidationError(GroupViolation, names[0], "one of %v is required", joinNames(names, "or"))
+			}
+		case gt.Requires:
+			if len(names) > 1 && len(given) > 0 && given[0] == names[0] && len(missing) > 0 {
+				return newValidationError(GroupViolation, names[0], "parameter %v requires %v", names[0], joinNames(missing, "and"))
+			}
+		}
+	}
+	return nil
+}
+
+// Returns an error when a group has no params or references a param that is
+// not declared by the command
+func checkGroupDefinitions(command gt.Command) error {
+	for i, group := range command.Groups {
+		if len(group.Params) == 0 {
+			return fmt.Errorf("group %v of command %v has no params", i, command.Name)
+		}
+		for _, name := range group.Params {
+			declared := slices.ContainsFunc(command.Params, func(param gt.Param) bool {
+				return param.Name == name || slices.Contains(param.Aliases, name)
+			})
+			if !declared {
+				return fmt.Errorf("group %v of command %v references the undeclared param %v", i, command.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Groups may reference params by any of their aliases, parsed params are stored by Name
+func getGroupParamNames(group gt.ParamGroup, params []gt.Param) []string {
+	names := make([]string, 0, len(group.Params))
+	for _, name := range group.Params {
+		if param, ok := FindParam(name, params); ok {
+			name = param.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// Joins a list of names as "a, b and c"
+func joinNames(names []string, conjunction string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " " + conjunction + " " + names[len(names)-1]
+}
@@ -0,0 +1,91 @@
+package goclivalidation
+
+import (
+	"errors"
+	"testing"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+type groupTest struct {
+	input string
+	code  ErrorCode
+	ok    bool
+}
+
+func runGroupTests(t *testing.T, commands []gt.Command, tests []groupTest) {
+	t.Helper()
+	for _, test := range tests {
+		_, _, err := ValidateCommand(commands, test.input)
+		if test.ok {
+			if err != nil {
+				t.Errorf("ValidateCommand(%q) returned error %v", test.input, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != test.code {
+			t.Errorf("ValidateCommand(%q) returned error %v, want code %v", test.input, err, test.code)
+		}
+	}
+}
+
+func TestParamGroups(t *testing.T) {
+	commands := []gt.Command{
+		{Name: "out", Params: []gt.Param{
+			{Name: "--json"},
+			{Name: "--table", Aliases: []string{"-t"}},
+			{Name: "--user", Type: gt.Text},
+			{Name: "--password", Type: gt.Text},
+		}, Groups: []gt.ParamGroup{
+			{Type: gt.MutuallyExclusive, Params: []string{"--json", "-t"}},
+			{Type: gt.Requires, Params: []string{"--password", "--user"}},
+		}},
+		{Name: "find", Params: []gt.Param{
+			{Name: "--id", Aliases: []string{"-i"}, Type: gt.Number},
+			{Name: "--name", Type: gt.Text},
+		}, Groups: []gt.ParamGroup{
+			{Type: gt.AtLeastOne, Params: []string{"-i", "--name"}},
+		}},
+		{Name: "empty", Groups: []gt.ParamGroup{{Type: gt.AtLeastOne}}},
+		{Name: "unknown", Params: []gt.Param{{Name: "--a"}}, Groups: []gt.ParamGroup{{Type: gt.AtLeastOne, Params: []string{"--b"}}}},
+	}
+
+	runGroupTests(t, commands, []groupTest{
+		{"out", 0, true},
+		{"out --json", 0, true},
+		{"out --json --table", GroupViolation, false},
+		{"out -t --json", GroupViolation, false},
+		{"out --user a", 0, true},
+		{"out --user a --password b", 0, true},
+		{"out --password b", GroupViolation, false},
+		{"find -i 1", 0, true},
+		{"find --name x", 0, true},
+		{"find", GroupViolation, false},
+		{"empty", InvalidDefinition, false},
+		{"unknown --a", InvalidDefinition, false},
+	})
+}
+
+func TestParamGroupsWithDefaults(t *testing.T) {
+	commands := []gt.Command{{Name: "out", Params: []gt.Param{
+		{Name: "--fmt", Type: gt.Text, Default: "json"},
+		{Name: "--out", Type: gt.Text},
+		{Name: "--color", Type: gt.Text, Default: "auto"},
+		{Name: "--plain"},
+		{Name: "--level", Type: gt.Number, Default: 1},
+		{Name: "--quiet"},
+	}, Groups: []gt.ParamGroup{
+		{Type: gt.Requires, Params: []string{"--fmt", "--out"}},
+		{Type: gt.MutuallyExclusive, Params: []string{"--color", "--plain"}},
+		{Type: gt.AtLeastOne, Params: []string{"--level", "--quiet"}},
+	}}}
+
+	runGroupTests(t, commands, []groupTest{
+		{"out", 0, true},
+		{"out --plain", 0, true},
+		{"out --fmt csv --out x", 0, true},
+		{"out --fmt csv", GroupViolation, false},
+		{"out --color red --plain", GroupViolation, false},
+	})
+}
@@ -0,0 +1,471 @@
+package goclivalidation
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gt "github.com/vcharco/gocli/internal/types"
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+type paramParser struct {
+	command gt.Command
+	params  map[string]interface{}
+	values  map[string][]interface{}
+	sources map[string]gt.ParamSource
+}
+
+func newParamParser(command gt.Command) *paramParser {
+	return &paramParser{command: command, params: map[string]interface{}{}, values: map[string][]interface{}{}, sources: map[string]gt.ParamSource{}}
+}
+
+func parseParams(candidate gt.Command, tokens []gu.Token) (ParsedInput, error) {
+	positionalParams, err := getPositionalParams(candidate.Params)
+
+	if err != nil {
+		return ParsedInput{}, wrapValidationError(InvalidDefinition, "", err)
+	}
+
+	if err := checkGroupDefinitions(candidate); err != nil {
+		return ParsedInput{}, wrapValidationError(InvalidDefinition, "", err)
+	}
+
+	if err := checkPatterns(candidate.Params); err != nil {
+		return ParsedInput{}, err
+	}
+
+	if err := checkDefaults(candidate.Params); err != nil {
+		return ParsedInput{}, err
+	}
+
+	p := newParamParser(candidate)
+
+	positionalValues, err := p.parse(tokens)
+	if err != nil {
+		return ParsedInput{}, err
+	}
+
+	args, err := p.setPositionalValues(positionalParams, positionalValues)
+	if err != nil {
+		return ParsedInput{}, err
+	}
+
+	err = p.setFallbackValues()
+	if err != nil {
+		return ParsedInput{}, err
+	}
+
+	err = checkParsedParams(p.params, p.sources, candidate)
+
+	if err != nil {
+		return ParsedInput{}, err
+	}
+
+	return ParsedInput{Params: p.params, Args: args, Sources: p.sources}, nil
+}
+
+// Assigns named params and returns the tokens left for the positional ones
+func (p *paramParser) parse(tokens []gu.Token) ([]gu.Token, error) {
+	var positionalValues []gu.Token
+	endOfOptions := false
+
+	for i := 0; i < len(tokens); i++ {
+		word := tokens[i].Value
+
+		// Everything after "--" is treated as a value
+		if word == "--" && !endOfOptions {
+			endOfOptions = true
+			continue
+		}
+
+		if !endOfOptions {
+			if param, err := getParamOrError(word, p.command.Params); err == nil {
+				if param.Type == gt.None {
+					p.setFlag(param)
+					continue
+				}
+				if i+1 >= len(tokens) {
+					return nil, locateError(newValidationError(MissingValue, param.Name, "missing value for parameter %v", word), tokens[i])
+				}
+				if _, err := p.setValue(param, tokens[i+1].Value); err != nil {
+					return nil, locateError(err, tokens[i+1])
+				}
+				i++
+				continue
+			}
+
+			matched, err := p.parseLongParam(word)
+			if err != nil {
+				return nil, locateError(err, tokens[i])
+			}
+			if matched {
+				continue
+			}
+
+			matched, pending, err := p.parseShortParams(word)
+			if err != nil {
+				return nil, locateError(err, tokens[i])
+			}
+			if pending != nil {
+				if i+1 >= len(tokens) {
+					return nil, locateError(newValidationError(MissingValue, pending.Name, "missing value for parameter %v", pending.Name), tokens[i])
+				}
+				if _, err := p.setValue(*pending, tokens[i+1].Value); err != nil {
+					return nil, locateError(err, tokens[i+1])
+				}
+				i++
+			}
+			if matched {
+				continue
+			}
+		}
+
+		// Before "--", a dash word that is not a param is a typo rather than a value
+		if !endOfOptions && LooksLikeParam(word) {
+			return nil, locateError(newValidationError(UnknownParam, "", "invalid parameter %v", word), tokens[i])
+		}
+
+		positionalValues = append(positionalValues, tokens[i])
+	}
+
+	return positionalValues, nil
+}
+
+// LooksLikeParam tells if the word starts with a dash and is not a negative
+// number, so it can only be a param name
+func LooksLikeParam(word string) bool {
+	_, err := strconv.ParseFloat(word, 64)
+	return len(word) > 1 && strings.HasPrefix(word, "-") && err != nil
+}
+
+// Parses --name=value
+func (p *paramParser) parseLongParam(word string) (bool, error) {
+	if !strings.HasPrefix(word, "--") {
+		return false, nil
+	}
+
+	name, value, found := strings.Cut(word, "=")
+	if !found {
+		return false, nil
+	}
+
+	param, err := getParamOrError(name, p.command.Params)
+	if err != nil {
+		return false, nil
+	}
+
+	if param.Type == gt.None {
+		return false, newValidationError(UnexpectedValue, param.Name, "parameter %v does not accept a value", name)
+	}
+
+	_, err = p.setValue(param, value)
+	return true, err
+}
+
+// Parses bundled short flags (-abc) and short params followed by their value (-n5, -abn5).
+// If the last short param needs a value not included in the word (-abn 5), it is
+// returned as pending so the caller takes the value from the next word.
+func (p *paramParser) parseShortParams(word string) (bool, *gt.Param, error) {
+	if len(word) < 3 || word[0] != '-' || word[1] == '-' {
+		return false, nil, nil
+	}
+
+	// Resolve everything before assigning, so a word like -5 is left for the default param
+	var flags []gt.Param
+	var valued *gt.Param
+	value := ""
+	for i := 1; i < len(word); i++ {
+		param, err := getParamOrError("-"+word[i:i+1], p.command.Params)
+		if err != nil {
+			return false, nil, nil
+		}
+		if param.Type != gt.None {
+			valued = &param
+			value = word[i+1:]
+			break
+		}
+		flags = append(flags, param)
+	}
+
+	for _, flag := range flags {
+		p.setFlag(flag)
+	}
+
+	if valued == nil {
+		return true, nil, nil
+	}
+
+	if len(value) == 0 {
+		return true, valued, nil
+	}
+
+	_, err := p.setValue(*valued, value)
+	return true, nil, err
+}
+
+// Binds the positional values to the positional params in the order they were
+// declared. A variadic param takes all the remaining values.
+func (p *paramParser) setPositionalValues(params []gt.Param, values []gu.Token) ([]interface{}, error) {
+	var args []interface{}
+
+	for _, param := range params {
+		for len(values) > 0 {
+			casted, err := p.setValue(param, values[0].Value)
+			if err != nil {
+				return nil, locateError(err, values[0])
+			}
+			args = append(args, casted...)
+			values = values[1:]
+
+			if !gt.IsVariadic(param) {
+				break
+			}
+		}
+	}
+
+	if len(values) > 0 {
+		return nil, locateError(newValidationError(UnknownParam, "", "invalid parameter %v", values[0].Value), values[0])
+	}
+
+	return args, nil
+}
+
+// Gives the named params that were not typed the value of their environment
+// variable or, when it is not set, their default value
+func (p *paramParser) setFallbackValues() error {
+	for _, param := range p.command.Params {
+		if _, exists := p.params[param.Name]; exists || !gt.HasFallback(param) {
+			continue
+		}
+
+		if value, ok := os.LookupEnv(param.EnvVar); ok && len(param.EnvVar) > 0 {
+			if param.Type == gt.None {
+				flag, err := parseFlagValue(value)
+				if err != nil {
+					return newValidationError(InvalidEnvValue, param.Name, "environment variable %v of parameter %v must be a boolean", param.EnvVar, param.Name)
+				}
+				if flag {
+					p.setFlag(param)
+				}
+			} else if _, err := p.setValue(param, value); err != nil {
+				return newValidationError(InvalidEnvValue, param.Name, "environment variable %v: %v", param.EnvVar, err)
+			}
+			if _, exists := p.params[param.Name]; exists {
+				p.sources[param.Name] = gt.FromEnv
+			}
+			continue
+		}
+
+		if param.Default != nil {
+			value, err := getDefaultValue(param)
+			if err != nil {
+				return wrapValidationError(InvalidDefinition, param.Name, err)
+			}
+			p.params[param.Name] = value
+			p.sources[param.Name] = gt.FromDefault
+		}
+	}
+	return nil
+}
+
+// Returns the Default of the param. A text is parsed as if it was typed, and
+// any other value must already have the type of the param and meet its
+// constraints.
+func getDefaultValue(param gt.Param) (interface{}, error) {
+	if text, ok := param.Default.(string); ok {
+		value, err := parseDefaultValue(param, text)
+		if err != nil {
+			return nil, fmt.Errorf("default value of parameter %v: %v", param.Name, err)
+		}
+		return value, nil
+	}
+
+	if param.Type == gt.None {
+		if _, ok := param.Default.(bool); !ok {
+			return nil, fmt.Errorf("default value of flag %v must be a bool", param.Name)
+		}
+		return param.Default, nil
+	}
+
+	values := []reflect.Value{reflect.ValueOf(param.Default)}
+	if gt.IsMultiValued(param) && values[0].Kind() == reflect.Slice {
+		values = nil
+		for i := 0; i < reflect.ValueOf(param.Default).Len(); i++ {
+			values = append(values, reflect.ValueOf(param.Default).Index(i))
+		}
+	}
+	for _, value := range values {
+		if err := checkConstraints(param, fmt.Sprint(value.Interface()), value.Interface()); err != nil {
+			return nil, fmt.Errorf("default value of parameter %v: %v", param.Name, err)
+		}
+	}
+	return param.Default, nil
+}
+
+// Returns an error when the Default of a param doesn't fit in it
+func checkDefaults(params []gt.Param) error {
+	for _, param := range params {
+		if param.Default == nil || gt.IsPositional(param) {
+			continue
+		}
+		if _, err := getDefaultValue(param); err != nil {
+			return wrapValidationError(InvalidDefinition, param.Name, err)
+		}
+	}
+	return nil
+}
+
+// Parses a default value written as text, the same way it would be parsed if it
+// was typed in the terminal
+func parseDefaultValue(param gt.Param, value string) (interface{}, error) {
+	if param.Type == gt.None {
+		return parseFlagValue(value)
+	}
+
+	p := newParamParser(gt.Command{})
+	if _, err := p.setValue(param, value); err != nil {
+		return nil, err
+	}
+	return p.params[param.Name], nil
+}
+
+// ValidateValue checks a value of the param the same way it is checked when
+// parsing the command, including lists and constraints
+func ValidateValue(param gt.Param, value string) error {
+	_, err := newParamParser(gt.Command{}).setValue(param, value)
+	return err
+}
+
+// Flags are set from text with the same values the Bool type accepts
+func parseFlagValue(value string) (bool, error) {
+	parsed, err := CastParam(gt.Param{Type: gt.Bool}, value)
+	if err != nil {
+		return false, err
+	}
+	return parsed.(bool), nil
+}
+
+// Repeatable flags count how many times they were given
+func (p *paramParser) setFlag(param gt.Param) {
+	p.sources[param.Name] = gt.FromInput
+
+	if param.Modifier&gt.REPEATABLE == 0 {
+		p.params[param.Name] = true
+		return
+	}
+
+	count, _ := p.params[param.Name].(int)
+	p.params[param.Name] = count + 1
+}
+
+// Validates and casts the value, accumulating it when the param takes several
+// values. It returns the casted values.
+func (p *paramParser) setValue(param gt.Param, value string) ([]interface{}, error) {
+	if !gt.IsMultiValued(param) && p.sources[param.Name] == gt.FromInput {
+		if _, exists := p.params[param.Name]; exists {
+			return nil, newValidationError(InvalidCount, param.Name, "parameter %v cannot be given more than once", param.Name)
+		}
+	}
+
+	items := []string{value}
+	if param.Modifier&gt.LIST != 0 {
+		items = splitList(value)
+		if len(items) == 0 {
+			return nil, newValidationError(InvalidValue, param.Name, "parameter %v must be a list of values separated by commas", param.Name)
+		}
+	}
+
+	var casted []interface{}
+	for _, item := range items {
+		if err := ValidateType(param, item); err != nil {
+			return nil, wrapValidationError(InvalidValue, param.Name, err)
+		}
+
+		// Positional params without type keep the raw value
+		var c interface{} = item
+		if param.Type != gt.None {
+			var err error
+			if c, err = CastParam(param, item); err != nil {
+				return nil, wrapValidationError(InvalidValue, param.Name, err)
+			}
+		}
+
+		if err := checkConstraints(param, item, c); err != nil {
+			return nil, wrapValidationError(ConstraintViolation, param.Name, err)
+		}
+		casted = append(casted, c)
+	}
+
+	p.sources[param.Name] = gt.FromInput
+
+	if !gt.IsMultiValued(param) {
+		p.params[param.Name] = casted[0]
+		return casted, nil
+	}
+
+	p.values[param.Name] = append(p.values[param.Name], casted...)
+	p.params[param.Name] = makeTypedSlice(p.values[param.Name])
+	return casted, nil
+}
+
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// Converts a list of values of the same type into a slice of that type ([]int, []string...)
+func makeTypedSlice(items []interface{}) interface{} {
+	if len(items) == 0 {
+		return []interface{}{}
+	}
+
+	slice := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(items[0])), 0, len(items))
+	for _, item := range items {
+		slice = reflect.Append(slice, reflect.ValueOf(item))
+	}
+	return slice.Interface()
+}
+
+func checkParamCounts(parsedParams map[string]interface{}, params []gt.Param) error {
+	for _, param := range params {
+		if param.MinCount == 0 && param.MaxCount == 0 {
+			continue
+		}
+
+		verb := "must be given"
+		if gt.GetCountUnit(param) == "values" {
+			verb = "must have"
+		}
+
+		count := 0
+		if value, exists := parsedParams[param.Name]; exists {
+			count = getValueCount(param, value)
+		}
+
+		if count < param.MinCount {
+			return newValidationError(InvalidCount, param.Name, "parameter %v %v at least %v %v", param.Name, verb, param.MinCount, gt.GetCountUnit(param))
+		}
+		if param.MaxCount > 0 && count > param.MaxCount {
+			return newValidationError(InvalidCount, param.Name, "parameter %v %v at most %v %v", param.Name, verb, param.MaxCount, gt.GetCountUnit(param))
+		}
+	}
+	return nil
+}
+
+func getValueCount(param gt.Param, value interface{}) int {
+	if count, ok := value.(int); ok && param.Type == gt.None {
+		return count
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice {
+		return v.Len()
+	}
+
+	return 1
+}
@@ -0,0 +1,222 @@
+package goclivalidation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+var testCommands = []gt.Command{
+	{Name: "cmd", Params: []gt.Param{
+		{Name: "-a"},
+		{Name: "-b"},
+		{Name: "--force"},
+		{Name: "--num", Aliases: []string{"-n"}, Type: gt.Number},
+		{Name: "--name", Type: gt.Text},
+		{Name: "--tag", Type: gt.Text, Modifier: gt.REPEATABLE},
+		{Name: "--ports", Type: gt.Number, Modifier: gt.LIST},
+		{Name: "-v", Modifier: gt.REPEATABLE},
+		{Name: "first", Type: gt.Text, Modifier: gt.DEFAULT | gt.REQUIRED},
+		{Name: "rest", Type: gt.Text, Modifier: gt.DEFAULT | gt.VARIADIC},
+	}},
+	{Name: "calc", Params: []gt.Param{
+		{Name: "-x"},
+		{Name: "value", Type: gt.Number, Modifier: gt.DEFAULT},
+	}},
+}
+
+type parserTest struct {
+	input  string
+	params map[string]interface{}
+	args   []interface{}
+}
+
+func runParserTests(t *testing.T, tests []parserTest) {
+	t.Helper()
+	for _, test := range tests {
+		_, parsed, err := ValidateCommand(testCommands, test.input)
+		if err != nil {
+			t.Errorf("ValidateCommand(%q) returned error %v", test.input, err)
+			continue
+		}
+		for name, want := range test.params {
+			if got := parsed.Params[name]; !reflect.DeepEqual(got, want) {
+				t.Errorf("ValidateCommand(%q) param %v = %#v, want %#v", test.input, name, got, want)
+			}
+		}
+		if test.args != nil && !reflect.DeepEqual(parsed.Args, test.args) {
+			t.Errorf("ValidateCommand(%q) args = %#v, want %#v", test.input, parsed.Args, test.args)
+		}
+	}
+}
+
+func runParserErrorTests(t *testing.T, tests map[string]ErrorCode) {
+	t.Helper()
+	for input, code := range tests {
+		_, _, err := ValidateCommand(testCommands, input)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != code {
+			t.Errorf("ValidateCommand(%q) returned error %v, want code %v", input, err, code)
+		}
+	}
+}
+
+func TestNamedParams(t *testing.T) {
+	runParserTests(t, []parserTest{
+		{"cmd -a -b f", map[string]interface{}{"-a": true, "-b": true}, nil},
+		{"cmd -abn5 f", map[string]interface{}{"-a": true, "-b": true, "--num": 5}, nil},
+		{"cmd -abn 5 f", map[string]interface{}{"-a": true, "-b": true, "--num": 5}, nil},
+		{"cmd -n5 f", map[string]interface{}{"--num": 5}, nil},
+		{"cmd --num=7 f", map[string]interface{}{"--num": 7}, nil},
+		{"cmd --name=x f", map[string]interface{}{"--name": "x"}, nil},
+		{"cmd --name='a b' f", map[string]interface{}{"--name": "a b"}, nil},
+		{"cmd --name=a=b f", map[string]interface{}{"--name": "a=b"}, nil},
+		{"cmd -- -a", map[string]interface{}{"first": "-a", "-a": nil}, nil},
+		{"calc -5", map[string]interface{}{"value": -5}, nil},
+		{"calc -x -5", map[string]interface{}{"-x": true, "value": -5}, nil},
+	})
+
+	runParserErrorTests(t, map[string]ErrorCode{
+		"cmd f -n":        MissingValue,
+		"cmd f -n x":      InvalidValue,
+		"cmd --force=1 f": UnexpectedValue,
+		"cmd --bogus f":   UnknownParam,
+		"cmd f -zz":       UnknownParam,
+		"calc --x=1":      UnknownParam,
+	})
+}
+
+func TestPositionalParams(t *testing.T) {
+	runParserTests(t, []parserTest{
+		{"cmd f", map[string]interface{}{"first": "f", "rest": nil}, []interface{}{"f"}},
+		{"cmd f g h", map[string]interface{}{"first": "f", "rest": []string{"g", "h"}}, []interface{}{"f", "g", "h"}},
+		{"cmd f -a g", map[string]interface{}{"-a": true, "rest": []string{"g"}}, []interface{}{"f", "g"}},
+		{"cmd f -- -a --num", map[string]interface{}{"first": "f", "rest": []string{"-a", "--num"}}, []interface{}{"f", "-a", "--num"}},
+		{"calc", map[string]interface{}{"value": nil}, nil},
+	})
+
+	runParserErrorTests(t, map[string]ErrorCode{
+		"cmd":      MissingParam,
+		"calc 1 2": UnknownParam,
+		"calc x":   InvalidValue,
+	})
+}
+
+func TestPositionalDefinition(t *testing.T) {
+	commands := [][]gt.Param{
+		{{Name: "a", Modifier: gt.DEFAULT}, {Name: "b", Modifier: gt.DEFAULT | gt.REQUIRED}},
+		{{Name: "a", Modifier: gt.DEFAULT | gt.VARIADIC}, {Name: "b", Modifier: gt.DEFAULT}},
+	}
+
+	for _, params := range commands {
+		_, _, err := ValidateCommand([]gt.Command{{Name: "cmd", Params: params}}, "cmd x")
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != InvalidDefinition {
+			t.Errorf("ValidateCommand with params %+v returned error %v, want an InvalidDefinition", params, err)
+		}
+	}
+}
+
+func TestMultiValuedParams(t *testing.T) {
+	runParserTests(t, []parserTest{
+		{"cmd --tag a f --tag b", map[string]interface{}{"--tag": []string{"a", "b"}}, nil},
+		{"cmd --tag=a f", map[string]interface{}{"--tag": []string{"a"}}, nil},
+		{"cmd --ports 80,443 f", map[string]interface{}{"--ports": []int{80, 443}}, nil},
+		{"cmd --ports '80 443' f", map[string]interface{}{"--ports": []int{80, 443}}, nil},
+		{"cmd -vvv f", map[string]interface{}{"-v": 3}, nil},
+		{"cmd -v -av f", map[string]interface{}{"-v": 2, "-a": true}, nil},
+	})
+
+	runParserErrorTests(t, map[string]ErrorCode{
+		"cmd --name a --name b f": InvalidCount,
+		"cmd -n1 --num=2 f":       InvalidCount,
+		"cmd --ports 80,x f":      InvalidValue,
+	})
+}
+
+func TestValueCounts(t *testing.T) {
+	commands := []gt.Command{{Name: "cmd", Params: []gt.Param{
+		{Name: "--tag", Type: gt.Text, Modifier: gt.REPEATABLE, MinCount: 1, MaxCount: 2},
+	}}}
+
+	for input, ok := range map[string]bool{"cmd --tag a": true, "cmd --tag a --tag b": true, "cmd --tag a --tag b --tag c": false} {
+		_, _, err := ValidateCommand(commands, input)
+		var validationErr *ValidationError
+		if ok != (err == nil) || (!ok && (!errors.As(err, &validationErr) || validationErr.Code != InvalidCount)) {
+			t.Errorf("ValidateCommand(%q) returned error %v", input, err)
+		}
+	}
+}
+
+func TestFallbackValues(t *testing.T) {
+	t.Setenv("TEST_GOCLI_PORT", "9000")
+	t.Setenv("TEST_GOCLI_DEBUG", "yes")
+	t.Setenv("TEST_GOCLI_BAD", "x")
+
+	commands := []gt.Command{{Name: "srv", Params: []gt.Param{
+		{Name: "--port", Type: gt.Number, EnvVar: "TEST_GOCLI_PORT", Default: 80},
+		{Name: "--host", Type: gt.Text, EnvVar: "TEST_GOCLI_UNSET", Default: "localhost"},
+		{Name: "--workers", Type: gt.Number, Default: "4"},
+		{Name: "--tags", Type: gt.Text, Modifier: gt.LIST, Default: "a,b"},
+		{Name: "--debug", EnvVar: "TEST_GOCLI_DEBUG"},
+	}}}
+
+	tests := []struct {
+		input   string
+		params  map[string]interface{}
+		sources map[string]gt.ParamSource
+	}{
+		{"srv",
+			map[string]interface{}{"--port": 9000, "--host": "localhost", "--workers": 4, "--tags": []string{"a", "b"}, "--debug": true},
+			map[string]gt.ParamSource{"--port": gt.FromEnv, "--host": gt.FromDefault, "--workers": gt.FromDefault, "--debug": gt.FromEnv}},
+		{"srv --port 1 --host h",
+			map[string]interface{}{"--port": 1, "--host": "h"},
+			map[string]gt.ParamSource{"--port": gt.FromInput, "--host": gt.FromInput}},
+	}
+
+	for _, test := range tests {
+		_, parsed, err := ValidateCommand(commands, test.input)
+		if err != nil {
+			t.Errorf("ValidateCommand(%q) returned error %v", test.input, err)
+			continue
+		}
+		for name, want := range test.params {
+			if got := parsed.Params[name]; !reflect.DeepEqual(got, want) {
+				t.Errorf("ValidateCommand(%q) param %v = %#v, want %#v", test.input, name, got, want)
+			}
+		}
+		for name, want := range test.sources {
+			if got := parsed.Sources[name]; got != want {
+				t.Errorf("ValidateCommand(%q) source of %v = %v, want %v", test.input, name, got, want)
+			}
+		}
+	}
+
+	commands[0].Params = append(commands[0].Params, gt.Param{Name: "--bad", Type: gt.Number, EnvVar: "TEST_GOCLI_BAD"})
+	_, _, err := ValidateCommand(commands, "srv")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Code != InvalidEnvValue {
+		t.Errorf("ValidateCommand with an invalid environment variable returned error %v, want an InvalidEnvValue", err)
+	}
+}
+
+func TestDefaultDefinition(t *testing.T) {
+	params := []gt.Param{
+		{Name: "--port", Type: gt.Number, Default: "8080", Max: gt.Bound(100)},
+		{Name: "--port", Type: gt.Number, Default: 8080, Max: gt.Bound(100)},
+		{Name: "--port", Type: gt.Number, Default: "http"},
+		{Name: "--name", Type: gt.Text, Default: "ab", MinLength: 3},
+		{Name: "--tag", Type: gt.Text, Modifier: gt.REPEATABLE, Default: []string{"ok", "x"}, MinLength: 2},
+		{Name: "--force", Default: 1},
+	}
+
+	for _, param := range params {
+		_, _, err := ValidateCommand([]gt.Command{{Name: "cmd", Params: []gt.Param{param}}}, "cmd")
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != InvalidDefinition {
+			t.Errorf("ValidateCommand with default %#v returned error %v, want an InvalidDefinition", param.Default, err)
+		}
+	}
+}
@@ -3,16 +3,15 @@ package goclivalidation
 import (
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
+	"slices"
 
 	gt "github.com/vcharco/gocli/internal/types"
 	gu "github.com/vcharco/gocli/internal/utils"
 )
 
 func GetClosestCommand(candidates []gt.Command, command string) (gt.Command, error) {
-	words := strings.Fields(command)
+	tokens, _ := gu.Tokenize(command)
+	words := gu.TokenValues(tokens)
 
 	if len(words) == 0 {
 		return gt.Command{}, fmt.Errorf("empty command")
@@ -34,12 +33,33 @@ func GetClosestCommand(candidates []gt.Command, command string) (gt.Command, err
 	return candidate, nil
 }
 
-func ValidateCommand(candidates []gt.Command, command string) (gt.Command, map[string]interface{}, error) {
+// Result of parsing the params of a command. Params holds every param by name,
+// Args the positional values in the order they were typed and Sources where the
+// value of each param was taken from.
+type ParsedInput struct {
+	Params  map[string]interface{}
+	Args    []interface{}
+	Sources map[string]gt.ParamSource
+}
+
+// ValidateCommand parses the input of a command. The errors are of type *ValidationError.
+func ValidateCommand(candidates []gt.Command, command string) (gt.Command, ParsedInput, error) {
+
+	tokens, err := gu.Tokenize(command)
+	if err != nil {
+		validationErr := &ValidationError{Offset: len(command), Code: SyntaxError, Err: err}
+		var tokenizeErr *gu.TokenizeError
+		if errors.As(err, &tokenizeErr) {
+			validationErr.Offset = tokenizeErr.Pos
+			validationErr.Length = 1
+		}
+		return gt.Command{}, ParsedInput{}, validationErr
+	}
 
-	words := strings.Fields(command)
+	words := gu.TokenValues(tokens)
 
 	if len(words) == 0 {
-		return gt.Command{}, nil, errors.New("empty command")
+		return gt.Command{}, ParsedInput{}, &ValidationError{Code: EmptyCommand, Err: errors.New("empty command")}
 	}
 
 	candidate := gt.Command{Name: "", Params: []gt.Param{}}
@@ -52,122 +72,79 @@ func ValidateCommand(candidates []gt.Command, command string) (gt.Command, map[s
 	}
 
 	if len(candidate.Name) == 0 {
-		return gt.Command{}, nil, errors.New("invalid command")
-	}
-
-	if len(words) == 1 {
-		err := checkRequiredParams(nil, candidate.Params)
-		if err != nil {
-			return gt.Command{}, nil, err
-		}
-		return candidate, nil, nil
+		return gt.Command{}, ParsedInput{}, locateError(newValidationError(UnknownCommand, "", "invalid command"), tokens[0])
 	}
 
 	if len(words) > 1 && len(candidate.Params) == 0 {
-		return candidate, nil, fmt.Errorf("parameters not supported for this command")
+		err := locateError(newValidationError(ParamsNotSupported, "", "parameters not supported for this command"), tokens[1])
+		return candidate, ParsedInput{}, completeValidationError(err, candidate.Name, len(command))
 	}
 
-	params, err := ValidateParams(candidate, words[1:])
+	// Even without params typed, the fallback values and the required ones are checked
+	parsed, err := parseParams(candidate, tokens[1:])
+	err = completeValidationError(err, candidate.Name, len(command))
 
-	return candidate, params, err
+	return candidate, parsed, err
 }
 
-func ValidateParams(candidate gt.Command, inputParams []string) (map[string]interface{}, error) {
-	parsedParams := map[string]interface{}{}
-
-	if len(inputParams) == 0 {
-		parsedParams[candidate.Name] = nil
-		return parsedParams, nil
-	}
-
-	defaultParam, err := getDefaultParam(candidate.Params)
-
-	if err != nil {
-		return nil, err
-	}
-
-	checkedDefaultParam := len(defaultParam.Name) == 0
-
-	for i := 0; i < len(inputParams); i++ {
-		param, err := getParamOrError(inputParams[i], candidate.Params)
-		if err != nil {
-			if !checkedDefaultParam {
-				err := ValidateType(*defaultParam, inputParams[i])
-				if err != nil {
-					return nil, err
-				}
-
-				casted, err := CastParam(*defaultParam, inputParams[i])
-				if err != nil {
-					return nil, err
-				}
-
-				parsedParams[defaultParam.Name] = casted
-				checkedDefaultParam = true
-				continue
-			} else {
-				return nil, fmt.Errorf("invalid parameter %v", inputParams[i])
-			}
-		}
-		if param.Type == gt.None {
-			parsedParams[param.Name] = true
-		} else {
-			if i+1 < len(inputParams) {
-				err := ValidateType(param, inputParams[i+1])
-				if err != nil {
-					return nil, err
-				}
-				casted, err := CastParam(param, inputParams[i+1])
-				if err != nil {
-					return nil, err
-				}
-				parsedParams[param.Name] = casted
-				i++
-			} else {
-				return nil, errors.New("missing value")
-			}
-		}
+// Checks the rules that involve the whole set of params once they are parsed
+func checkParsedParams(parsedParams map[string]interface{}, sources map[string]gt.ParamSource, command gt.Command) error {
+	if err := checkRequiredParams(parsedParams, command.Params); err != nil {
+		return err
 	}
-
-	err = checkRequiredParams(parsedParams, candidate.Params)
-
-	if err != nil {
-		return nil, err
+	if err := checkParamCounts(parsedParams, command.Params); err != nil {
+		return err
 	}
-
-	return parsedParams, nil
+	return checkParamGroups(parsedParams, sources, command)
 }
 
 func checkRequiredParams(parsedParams map[string]interface{}, params []gt.Param) error {
 	for _, param := range params {
 		_, exists := parsedParams[param.Name]
 		if !exists && param.Modifier&gt.REQUIRED != 0 {
-			if param.Modifier&gt.DEFAULT != 0 {
-				return fmt.Errorf("default parameter <%v> is required", GetValidationTypeName(param.Type))
+			if gt.IsPositional(param) {
+				return newValidationError(MissingParam, param.Name, "parameter <%v> is required", param.Name)
 			} else {
-				return fmt.Errorf("parameter %v is required", param.Name)
+				return newValidationError(MissingParam, param.Name, "parameter %v is required", param.Name)
 			}
 		}
 	}
 	return nil
 }
 
-func getDefaultParam(params []gt.Param) (*gt.Param, error) {
-	candidate := gt.Param{Name: ""}
+// Returns the positional params in the order they were declared. Required ones
+// must come first and only the last one may be variadic.
+func getPositionalParams(params []gt.Param) ([]gt.Param, error) {
+	var positionals []gt.Param
+	optionalFound := false
 	for _, param := range params {
-		if param.Modifier&gt.DEFAULT != 0 {
-			if len(candidate.Name) > 0 {
-				return nil, fmt.Errorf("cannot exist more than one default param")
-			}
-			candidate = param
+		if !gt.IsPositional(param) {
+			continue
+		}
+		if len(positionals) > 0 && gt.IsVariadic(positionals[len(positionals)-1]) {
+			return nil, fmt.Errorf("variadic param %v must be the last positional param", positionals[len(positionals)-1].Name)
+		}
+		if param.Modifier&gt.REQUIRED == 0 {
+			optionalFound = true
+		} else if optionalFound {
+			return nil, fmt.Errorf("required positional param %v cannot follow an optional one", param.Name)
 		}
+		positionals = append(positionals, param)
 	}
-	return &candidate, nil
+	return positionals, nil
+}
+
+func FindParam(name string, params []gt.Param) (gt.Param, bool) {
+	param, err := getParamOrError(name, params)
+	return param, err == nil
 }
 
 func getParamOrError(param string, params []gt.Param) (gt.Param, error) {
 	for _, p := range params {
-		if p.Name == param {
+		if gt.IsPositional(p) {
+			continue
+		}
+		if p.Name == param || slices.Contains(p.Aliases, param) {
 			return p, nil
 		}
 	}
@@ -175,144 +152,41 @@ func getParamOrError(param string, params []gt.Param) (gt.Param, error) {
 }
 
 func CastParam(param gt.Param, value string) (interface{}, error) {
-	if param.Type == gt.Number {
-		toInt, err := strconv.Atoi(value)
-		if err != nil {
-			return nil, errors.New("error when casting the Numeric param to an Integer")
-		}
-		return toInt, nil
-	} else if param.Type == gt.FloatNumber {
-		toFloat, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			return nil, errors.New("error when casting the Numeric param to a Float")
-		}
-		return toFloat, nil
-	} else if param.Type == gt.None {
-		return true, nil
-	} else {
+	definition, ok := GetTypeDefinition(param.Type)
+	if !ok {
+		return nil, fmt.Errorf("parameter %v has a unrecognized type", param.Name)
+	}
+
+	if definition.Convert == nil {
 		return value, nil
 	}
+
+	return definition.Convert(param, value)
 }
 
 func ValidateType(param gt.Param, inputParam string) error {
-	switch param.Type {
-	case gt.None:
-		return nil
-	case gt.Text:
-		if inputParam == "" {
-			return fmt.Errorf("text cannot be empty")
-		}
-		return nil
-
-	case gt.Number:
-		if _, err := strconv.Atoi(inputParam); err != nil {
-			return fmt.Errorf("parameter %v must be a number", param.Name)
-		}
-		return nil
-
-	case gt.FloatNumber:
-		if _, err := strconv.ParseFloat(inputParam, 64); err != nil {
-			return fmt.Errorf("parameter %v must be a float number", param.Name)
-		}
-		return nil
-
-	case gt.Ipv4:
-		re := regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be an IPv4", param.Name)
-		}
-		return nil
-
-	case gt.Ipv6:
-		re := regexp.MustCompile(`([0-9a-fA-F]{1,4}:){7}[0-9a-fA-F]{1,4}`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be an IPv6", param.Name)
-		}
-		return nil
-
-	case gt.Email:
-		re := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be an email address", param.Name)
-		}
-		return nil
-
-	case gt.Domain:
-		re := regexp.MustCompile(`^(?:[a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a domain name", param.Name)
-		}
-		return nil
-
-	case gt.Phone:
-		re := regexp.MustCompile(`^\+?[0-9]{10,15}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a phone number", param.Name)
-		}
-		return nil
-
-	case gt.Date:
-		re := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a date (YYYY-MM-DD)", param.Name)
-		}
-		return nil
-
-	case gt.Time:
-		re := regexp.MustCompile(`^(?:[01]\d|2[0-3]):[0-5]\d$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a time (HH:mm)", param.Name)
-		}
-		return nil
-
-	case gt.Url:
-		re := regexp.MustCompile(`^https?://(?:[a-zA-Z]|[0-9]|[$-_@.&+]|[!*\\(\\),]|(?:%[0-9a-fA-F][0-9a-fA-F]))+$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a URL", param.Name)
-		}
-		return nil
+	definition, ok := GetTypeDefinition(param.Type)
+	if !ok {
+		return fmt.Errorf("parameter %v has a unrecognized type", param.Name)
+	}
 
-	case gt.UUID:
-		re := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89ab][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`)
-		if !re.MatchString(inputParam) {
-			return fmt.Errorf("parameter %v must be a UUID (v4)", param.Name)
-		}
+	if definition.Validate == nil {
 		return nil
-
-	default:
-		return fmt.Errorf("parameter %v has a unrecognized type", param.Name)
 	}
+
+	return definition.Validate(param, inputParam)
 }
 
 func GetValidationTypeName(val gt.ParamType) string {
-	switch val {
-	case gt.None:
-		return "None"
-	case gt.Date:
-		return "Date"
-	case gt.Domain:
-		return "Domain"
-	case gt.Email:
-		return "Email"
-	case gt.Ipv4:
-		return "Ipv4"
-	case gt.Ipv6:
-		return "Ipv6"
-	case gt.Number:
-		return "Number"
-	case gt.FloatNumber:
-		return "FloatNumber"
-	case gt.Phone:
-		return "Phone"
-	case gt.Text:
-		return "Text"
-	case gt.Time:
-		return "Time"
-	case gt.Url:
-		return "Url"
-	case gt.UUID:
-		return "UUID"
-	}
+	definition, _ := GetTypeDefinition(val)
+	return definition.Name
+}
 
-	return ""
+// Returns the values that may be suggested for the param when autocompleting
+func GetValueCompletions(param gt.Param, prefix string) []string {
+	definition, ok := GetTypeDefinition(param.Type)
+	if !ok || definition.Complete == nil {
+		return nil
+	}
+	return definition.Complete(param, prefix)
 }
@@ -0,0 +1,66 @@
+package goclivalidation
+
+import (
+	"sync"
+
+	gt "github.com/vcharco/gocli/internal/types"
+)
+
+// TypeDefinition describes how the values of a param type are validated, converted
+// and completed. Name is the label displayed in the help. Validate, Convert and
+// Complete are optional: without Validate any value is accepted, without Convert
+// the value is returned as a string and without Complete nothing is suggested.
+type TypeDefinition struct {
+	Name     string
+	Validate func(param gt.Param, value string) error
+	Convert  func(param gt.Param, value string) (interface{}, error)
+	Complete func(param gt.Param, prefix string) []string
+}
+
+var typeRegistry = struct {
+	sync.RWMutex
+	types map[gt.ParamType]TypeDefinition
+	next  gt.ParamType
+}{types: map[gt.ParamType]TypeDefinition{}}
+
+// RegisterType adds a new param type and returns the value to be used in Param.Type
+func RegisterType(definition TypeDefinition) gt.ParamType {
+	typeRegistry.Lock()
+	defer typeRegistry.Unlock()
+
+	paramType := typeRegistry.next
+	typeRegistry.types[paramType] = definition
+	typeRegistry.next++
+	return paramType
+}
+
+// Used for the built-in types, which have fixed values
+func setTypeDefinition(paramType gt.ParamType, definition TypeDefinition) {
+	typeRegistry.Lock()
+	defer typeRegistry.Unlock()
+
+	typeRegistry.types[paramType] = definition
+	if paramType >= typeRegistry.next {
+		typeRegistry.next = paramType + 1
+	}
+}
+
+func GetTypeDefinition(paramType gt.ParamType) (TypeDefinition, bool) {
+	typeRegistry.RLock()
+	defer typeRegistry.RUnlock()
+
+	definition, ok := typeRegistry.types[paramType]
+	return definition, ok
+}
+
+func GetTypeByName(name string) (gt.ParamType, bool) {
+	typeRegistry.RLock()
+	defer typeRegistry.RUnlock()
+
+	for paramType, definition := range typeRegistry.types {
+		if definition.Name == name {
+			return paramType, true
+		}
+	}
+	return gt.None, false
+}
@@ -0,0 +1,105 @@
+package goclivalidation
+
+import (
+	"errors"
+	"fmt"
+
+	gu "github.com/vcharco/gocli/internal/utils"
+)
+
+type ErrorCode int
+
+const (
+	// The input could not be split into words, like an unterminated quote
+	SyntaxError ErrorCode = iota
+	EmptyCommand
+	UnknownCommand
+	// Params were given to a command that has none
+	ParamsNotSupported
+	// A word that is neither a param nor a value of a positional param
+	UnknownParam
+	MissingValue
+	// A value given to a flag, like --force=yes
+	UnexpectedValue
+	// The value doesn't match the type of the param
+	InvalidValue
+	// The value breaks the range, length or pattern of the param
+	ConstraintViolation
+	// A required param was not given
+	MissingParam
+	// The param was given less or more times than MinCount or MaxCount
+	InvalidCount
+	// A rule of the groups of the command is broken
+	GroupViolation
+	// The environment variable of a param holds an invalid value
+	InvalidEnvValue
+	// The params of the command are declared in an invalid way
+	InvalidDefinition
+)
+
+// ValidationError is the error returned when the input doesn't match the command.
+// Offset and Length locate the offending token in the raw input. When the error
+// isn't caused by a token, like a missing param, Token is empty, Offset is the
+// length of the input and Length is 0.
+type ValidationError struct {
+	Command string
+	Param   string
+	Token   string
+	Offset  int
+	Length  int
+	Code    ErrorCode
+	Err     error
+}
+
+// Errors built by hand may have no Err, so the message is made from the code
+func (e *ValidationError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if len(e.Token) > 0 {
+		return fmt.Sprintf("validation error %v at %q", e.Code, e.Token)
+	}
+	return fmt.Sprintf("validation error %v", e.Code)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func newValidationError(code ErrorCode, param string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Param: param, Offset: -1, Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Wraps the errors returned by the type definitions and other helpers, keeping
+// the ones that are already validation errors
+func wrapValidationError(code ErrorCode, param string, err error) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return err
+	}
+	return &ValidationError{Param: param, Offset: -1, Code: code, Err: err}
+}
+
+// Points the error to the token that caused it, unless it is already located
+func locateError(err error, token gu.Token) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr.Offset < 0 {
+		validationErr.Token = token.Value
+		validationErr.Offset = token.Start
+		validationErr.Length = token.End - token.Start
+	}
+	return err
+}
+
+// Sets the command of the error, and points the errors without token to the end
+// of the input
+func completeValidationError(err error, command string, end int) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		validationErr.Command = command
+		if validationErr.Offset < 0 {
+			validationErr.Offset = end
+		}
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package goclivalidation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrorLocation(t *testing.T) {
+	tests := []struct {
+		input  string
+		code   ErrorCode
+		offset int
+	}{
+		{"", EmptyCommand, 0},
+		{"cmd 'f", SyntaxError, 4},
+		{"foo", UnknownCommand, 0},
+		{"cmd", MissingParam, 3},
+		{"cmd f -n", MissingValue, 6},
+		{"cmd f -n x", InvalidValue, 9},
+		{"cmd --force=1 f", UnexpectedValue, 4},
+		{"cmd --ports 80,x f", InvalidValue, 12},
+		{"calc 1 2", UnknownParam, 7},
+	}
+
+	for _, test := range tests {
+		_, _, err := ValidateCommand(testCommands, test.input)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("ValidateCommand(%q) returned error %v, want a ValidationError", test.input, err)
+			continue
+		}
+		if validationErr.Code != test.code || validationErr.Offset != test.offset {
+			t.Errorf("ValidateCommand(%q) returned code %v at %v, want code %v at %v", test.input, validationErr.Code, validationErr.Offset, test.code, test.offset)
+		}
+	}
+}
+
+func TestValidationErrorWithoutErr(t *testing.T) {
+	for _, err := range []*ValidationError{{}, {Code: UnknownParam, Token: "-x"}} {
+		if len(err.Error()) == 0 {
+			t.Errorf("Error() of %+v returned an empty message", err)
+		}
+	}
+}